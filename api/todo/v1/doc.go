@@ -0,0 +1,5 @@
+// Package todov1 holds the protobuf messages and gRPC services of the API,
+// generated from the .proto files of this directory.
+package todov1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative api/todo/v1/auth.proto api/todo/v1/list.proto api/todo/v1/item.proto
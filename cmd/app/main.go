@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/handler"
 	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/IvanMeln1k/go-todo-app/internal/rpc"
 	"github.com/IvanMeln1k/go-todo-app/internal/server"
 	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/IvanMeln1k/go-todo-app/internal/worker"
+	"github.com/IvanMeln1k/go-todo-app/pkg/blobstore"
 	"github.com/IvanMeln1k/go-todo-app/pkg/database"
+	"github.com/IvanMeln1k/go-todo-app/pkg/eventbus"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
@@ -43,9 +50,40 @@ func main() {
 		Password: "redis",
 	})
 
-	repos := repository.NewRepository(db, rdb)
+	blobs, err := newBlobStore()
+	if err != nil {
+		logrus.Fatalf("error initializing blob store: %s", err.Error())
+	}
+
+	bus, err := newEventBus(rdb)
+	if err != nil {
+		logrus.Fatalf("error initializing event bus: %s", err.Error())
+	}
+
+	repos := repository.NewRepository(db, rdb, blobs, bus)
 	services := service.NewService(repos)
 	handlers := handler.NewHandler(services)
+	rpcHandlers := rpc.NewHandler(services)
+
+	trashPurger := worker.NewTrashPurger(services.Trash, viper.GetDuration("trash.retention"),
+		viper.GetDuration("trash.purgeInterval"))
+	go trashPurger.Run(context.Background())
+
+	blobCleaner := worker.NewBlobCleaner(services.Attachment, viper.GetDuration("attachments.cleanupInterval"))
+	go blobCleaner.Run(context.Background())
+
+	webhookDispatcher := worker.NewWebhookDispatcher(services.Webhook, viper.GetDuration("webhooks.dispatchInterval"))
+	go webhookDispatcher.Run(context.Background())
+
+	outboxRelay := worker.NewOutboxRelay(services.Outbox, viper.GetDuration("events.relayInterval"))
+	go outboxRelay.Run(context.Background())
+
+	grpcSrv := new(server.GRPCServer)
+	go func() {
+		if err := grpcSrv.Run(viper.GetString("grpcPort"), rpcHandlers.InitServer()); err != nil {
+			logrus.Fatalf("error occured while running grpc server: %s", err.Error())
+		}
+	}()
 
 	srv := new(server.Server)
 	if err := srv.Run(viper.GetString("port"), handlers.InitRoutes()); err != nil {
@@ -59,6 +97,34 @@ func initConfig() error {
 	return viper.ReadInConfig()
 }
 
+func newBlobStore() (repository.BlobStore, error) {
+	switch storage := viper.GetString("attachments.storage"); storage {
+	case "local":
+		return blobstore.NewLocalStore(viper.GetString("attachments.dir"))
+	case "s3":
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  viper.GetString("attachments.s3.endpoint"),
+			Region:    viper.GetString("attachments.s3.region"),
+			Bucket:    viper.GetString("attachments.s3.bucket"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown attachments storage %q", storage)
+	}
+}
+
+func newEventBus(rdb *redis.Client) (repository.EventBus, error) {
+	switch bus := viper.GetString("events.bus"); bus {
+	case "memory":
+		return eventbus.NewMemoryBus(), nil
+	case "redis":
+		return eventbus.NewRedisBus(rdb, viper.GetString("events.stream"), viper.GetString("events.consumer")), nil
+	default:
+		return nil, fmt.Errorf("unknown event bus %q", bus)
+	}
+}
+
 // func main() {
 // 	rdb := database.NewRedisDB(database.RedisConfig{
 // 		Host:     "127.0.0.1",
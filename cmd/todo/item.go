@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/IvanMeln1k/go-todo-app/pkg/client"
+)
+
+var itemCommands = map[string]command{
+	"ls":   listItems,
+	"add":  addItem,
+	"edit": editItem,
+	"done": completeItem,
+	"rm":   removeItem,
+}
+
+func listItems(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("items ls", flag.ContinueOnError)
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoListId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		todoItems, err := c.GetItems(ctx, todoListId)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, 0, len(todoItems))
+		for _, todoItem := range todoItems {
+			rows = append(rows, itemRow(todoItem))
+		}
+		return a.print(todoItems, itemHeader, rows)
+	})
+}
+
+func addItem(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("items add", flag.ContinueOnError)
+	description := flags.String("description", "", "description of the item")
+	positional, err := parseArgs(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	todoListId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	todoItem := client.TodoItem{Title: positional[1]}
+	if isSet(flags, "description") {
+		todoItem.Description = description
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		id, err := c.CreateItem(ctx, todoListId, todoItem)
+		if err != nil {
+			return err
+		}
+		return a.printId(id)
+	})
+}
+
+// editItem changes the given fields of an item, on the same terms as
+// editList.
+func editItem(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("items edit", flag.ContinueOnError)
+	title := flags.String("title", "", "new title")
+	description := flags.String("description", "", "new description, empty to remove it")
+	done := flags.Bool("done", false, "whether the item is done")
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoItemId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.replaceItem(ctx, todoItemId, func(replacement *client.ReplaceTodoItem) {
+		if isSet(flags, "title") {
+			replacement.Title = *title
+		}
+		if isSet(flags, "description") {
+			replacement.Description = optional(*description)
+		}
+		if isSet(flags, "done") {
+			replacement.Done = *done
+		}
+	})
+}
+
+func completeItem(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("items done", flag.ContinueOnError)
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoItemId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.replaceItem(ctx, todoItemId, func(replacement *client.ReplaceTodoItem) {
+		replacement.Done = true
+	})
+}
+
+// replaceItem applies edit to the current fields of an item and replaces it,
+// on the condition that the item has not changed since it was read.
+func (a *app) replaceItem(ctx context.Context, todoItemId int, edit func(replacement *client.ReplaceTodoItem)) error {
+	return a.withClient(func(c *client.Client) error {
+		todoItem, err := c.GetItem(ctx, todoItemId)
+		if err != nil {
+			return err
+		}
+
+		replacement := todoItem.Replacement()
+		replacement.Version = &todoItem.Version
+		edit(&replacement)
+
+		todoItem, err = c.ReplaceItem(ctx, todoItemId, replacement)
+		if err != nil {
+			return err
+		}
+		return a.print(todoItem, itemHeader, [][]string{itemRow(todoItem)})
+	})
+}
+
+func removeItem(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("items rm", flag.ContinueOnError)
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoItemId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		return c.DeleteItem(ctx, todoItemId, nil)
+	})
+}
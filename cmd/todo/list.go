@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/pkg/client"
+)
+
+var listCommands = map[string]command{
+	"ls":   listLists,
+	"add":  addList,
+	"edit": editList,
+	"rm":   removeList,
+}
+
+func listLists(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("lists ls", flag.ContinueOnError)
+	archived := flags.Bool("archived", false, "list archived lists")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		todoLists, err := c.GetLists(ctx, *archived)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, 0, len(todoLists))
+		for _, todoList := range todoLists {
+			rows = append(rows, listRow(todoList))
+		}
+		return a.print(todoLists, listHeader, rows)
+	})
+}
+
+func addList(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("lists add", flag.ContinueOnError)
+	description := flags.String("description", "", "description of the list")
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	todoList := client.TodoList{Title: positional[0]}
+	if isSet(flags, "description") {
+		todoList.Description = description
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		id, err := c.CreateList(ctx, todoList)
+		if err != nil {
+			return err
+		}
+		return a.printId(id)
+	})
+}
+
+// editList changes the given fields of a list. The replacement is
+// conditional on the version it was read at, so concurrent edits are not
+// lost.
+func editList(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("lists edit", flag.ContinueOnError)
+	title := flags.String("title", "", "new title")
+	description := flags.String("description", "", "new description, empty to remove it")
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoListId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		todoList, err := c.GetList(ctx, todoListId)
+		if err != nil {
+			return err
+		}
+
+		replacement := todoList.Replacement()
+		replacement.Version = &todoList.Version
+		if isSet(flags, "title") {
+			replacement.Title = *title
+		}
+		if isSet(flags, "description") {
+			replacement.Description = optional(*description)
+		}
+
+		todoList, err = c.ReplaceList(ctx, todoListId, replacement)
+		if err != nil {
+			return err
+		}
+		return a.print(todoList, listHeader, [][]string{listRow(todoList)})
+	})
+}
+
+func removeList(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("lists rm", flag.ContinueOnError)
+	positional, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	todoListId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.withClient(func(c *client.Client) error {
+		return c.DeleteList(ctx, todoListId, nil)
+	})
+}
+
+func parseId(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%q is not an id", arg)
+	}
+	return id, nil
+}
+
+// isSet reports whether a flag was given, so an empty value can clear a
+// field.
+func isSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,287 @@
+// Command todo is a terminal client of the todo API.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/pkg/client"
+	"golang.org/x/term"
+)
+
+const (
+	defaultProfile = "default"
+	defaultServer  = "http://localhost:8000"
+)
+
+const usage = `Usage: todo [--profile NAME] [--json] COMMAND
+
+Commands:
+  login [--server URL] [--username NAME]   sign in, the password is read from TODO_PASSWORD or prompted
+  logout                                   end the session of the profile
+  profiles [use NAME]                      list the profiles or switch to one
+  lists ls [--archived]
+  lists add TITLE [--description TEXT]
+  lists edit ID [--title TITLE] [--description TEXT]
+  lists rm ID
+  items ls LIST_ID
+  items add LIST_ID TITLE [--description TEXT]
+  items edit ID [--title TITLE] [--description TEXT] [--done=BOOL]
+  items done ID
+  items rm ID
+`
+
+type app struct {
+	config  *config
+	profile string
+	json    bool
+	out     io.Writer
+}
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"login":    login,
+	"logout":   logout,
+	"profiles": profiles,
+	"lists":    subcommands(listCommands),
+	"items":    subcommands(itemCommands),
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "todo:", describe(err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("todo", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	profileName := flags.String("profile", os.Getenv("TODO_PROFILE"), "server profile to use")
+	asJSON := flags.Bool("json", false, "print JSON instead of tables")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	a := &app{config: cfg, profile: *profileName, json: *asJSON, out: os.Stdout}
+	if a.profile == "" {
+		a.profile = cfg.Current
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return errors.New("no command given")
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd(ctx, a, args[1:])
+}
+
+func subcommands(commands map[string]command) command {
+	return func(ctx context.Context, a *app, args []string) error {
+		if len(args) == 0 {
+			return errors.New("no subcommand given")
+		}
+		cmd, ok := commands[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown subcommand %q", args[0])
+		}
+		return cmd(ctx, a, args[1:])
+	}
+}
+
+// parseArgs parses flags that may come before, between or after the
+// positional arguments, e.g. "add Title --description text".
+func parseArgs(flags *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if len(positional) != n {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", flags.Name(), n, len(positional))
+	}
+	return positional, nil
+}
+
+// withClient runs fn with a client signed in to the profile, and keeps the
+// tokens if the client refreshed them.
+func (a *app) withClient(fn func(c *client.Client) error) error {
+	p, ok := a.config.Profiles[a.profile]
+	if !ok || p.RefreshToken == "" {
+		return fmt.Errorf("profile %q is not logged in, run todo login", a.profile)
+	}
+
+	c := client.New(p.Server, nil)
+	c.SetTokens(p.AccessToken, p.RefreshToken)
+	err := fn(c)
+
+	accessToken, refreshToken := c.Tokens()
+	if accessToken != p.AccessToken || refreshToken != p.RefreshToken {
+		p.AccessToken, p.RefreshToken = accessToken, refreshToken
+		if saveErr := a.config.save(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+func login(ctx context.Context, a *app, args []string) error {
+	p, ok := a.config.Profiles[a.profile]
+	if !ok {
+		p = &profile{Server: defaultServer}
+	}
+
+	flags := flag.NewFlagSet("login", flag.ContinueOnError)
+	server := flags.String("server", p.Server, "URL of the API")
+	username := flags.String("username", p.Username, "name to sign in with")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("login needs --username")
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	c := client.New(*server, nil)
+	if err = c.SignIn(ctx, *username, password); err != nil {
+		return err
+	}
+
+	p.Server, p.Username = *server, *username
+	p.AccessToken, p.RefreshToken = c.Tokens()
+	a.config.Profiles[a.profile] = p
+	a.config.Current = a.profile
+	if err = a.config.save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Logged in to %s as %s\n", p.Server, p.Username)
+	return nil
+}
+
+// readPassword takes the password from TODO_PASSWORD, or asks for it without
+// echo when stdin is a terminal.
+func readPassword() (string, error) {
+	if password, ok := os.LookupEnv("TODO_PASSWORD"); ok {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(password), err
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(password, "\r\n"), nil
+}
+
+func logout(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return errors.New("logout takes no arguments")
+	}
+
+	err := a.withClient(func(c *client.Client) error {
+		return c.Logout(ctx)
+	})
+	var clientErr *client.Error
+	if err != nil && !errors.As(err, &clientErr) {
+		return err
+	}
+
+	// The local session is dropped even if the server already ended it.
+	p := a.config.Profiles[a.profile]
+	p.AccessToken, p.RefreshToken = "", ""
+	return a.config.save()
+}
+
+func profiles(ctx context.Context, a *app, args []string) error {
+	if len(args) == 2 && args[0] == "use" {
+		if _, ok := a.config.Profiles[args[1]]; !ok {
+			return fmt.Errorf("no profile %q, log in with todo --profile %s login", args[1], args[1])
+		}
+		a.config.Current = args[1]
+		return a.config.save()
+	}
+	if len(args) != 0 {
+		return errors.New("usage: todo profiles [use NAME]")
+	}
+
+	names := make([]string, 0, len(a.config.Profiles))
+	for name := range a.config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Tokens are left out of the output.
+	type profileOutput struct {
+		Name     string `json:"name"`
+		Server   string `json:"server"`
+		Username string `json:"username"`
+		Current  bool   `json:"current"`
+	}
+	output := make([]profileOutput, 0, len(names))
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		p := a.config.Profiles[name]
+		current := name == a.config.Current
+		output = append(output, profileOutput{Name: name, Server: p.Server, Username: p.Username, Current: current})
+
+		marker := ""
+		if current {
+			marker = "*"
+		}
+		rows = append(rows, []string{marker, name, p.Server, p.Username})
+	}
+	return a.print(output, []string{"", "PROFILE", "SERVER", "USERNAME"}, rows)
+}
+
+// describe explains an API error, with the invalid fields of a validation
+// error.
+func describe(err error) string {
+	var clientErr *client.Error
+	if !errors.As(err, &clientErr) {
+		return err.Error()
+	}
+
+	message := clientErr.Detail
+	for _, field := range clientErr.Fields {
+		message += "\n  " + field.Field + ": " + field.Message
+	}
+	return message
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/handler"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mocks struct {
+	auth     *mock_service.MockAuthorization
+	todoList *mock_service.MockTodoList
+	todoItem *mock_service.MockTodoItem
+}
+
+// newTestApp returns an app logged in to a server with mocked services, with
+// the access token "access" and the refresh token "refresh". Its config is
+// kept in a temporary directory.
+func newTestApp(t *testing.T, asJSON bool) (*app, *bytes.Buffer, mocks) {
+	c := gomock.NewController(t)
+	m := mocks{
+		auth:     mock_service.NewMockAuthorization(c),
+		todoList: mock_service.NewMockTodoList(c),
+		todoItem: mock_service.NewMockTodoItem(c),
+	}
+
+	services := &service.Service{Authorization: m.auth, TodoList: m.todoList, TodoItem: m.todoItem}
+	server := httptest.NewServer(handler.NewHandler(services).InitRoutes())
+	t.Cleanup(server.Close)
+
+	cfg, err := loadConfig(filepath.Join(t.TempDir(), "config.json"))
+	require.NoError(t, err)
+	cfg.Profiles[defaultProfile] = &profile{
+		Server:       server.URL,
+		Username:     "user",
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+	}
+
+	out := &bytes.Buffer{}
+	return &app{config: cfg, profile: defaultProfile, json: asJSON, out: out}, out, m
+}
+
+func TestParseArgs(t *testing.T) {
+	testTable := []struct {
+		name               string
+		args               []string
+		n                  int
+		expectedPositional []string
+		expectedFlag       string
+		expectedError      string
+	}{
+		{
+			name:               "flag after the arguments",
+			args:               []string{"1", "title", "--description", "text"},
+			n:                  2,
+			expectedPositional: []string{"1", "title"},
+			expectedFlag:       "text",
+		},
+		{
+			name:               "flag between the arguments",
+			args:               []string{"1", "--description=text", "title"},
+			n:                  2,
+			expectedPositional: []string{"1", "title"},
+			expectedFlag:       "text",
+		},
+		{
+			name:               "no flags",
+			args:               []string{"1", "title"},
+			n:                  2,
+			expectedPositional: []string{"1", "title"},
+		},
+		{
+			name:          "too few arguments",
+			args:          []string{"1"},
+			n:             2,
+			expectedError: "items add takes 2 arguments, got 1",
+		},
+		{
+			name:          "unknown flag",
+			args:          []string{"1", "title", "--color", "red"},
+			n:             2,
+			expectedError: "flag provided but not defined: -color",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("items add", flag.ContinueOnError)
+			flags.SetOutput(&bytes.Buffer{})
+			description := flags.String("description", "", "")
+
+			positional, err := parseArgs(flags, testCase.args, testCase.n)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedPositional, positional)
+			assert.Equal(t, testCase.expectedFlag, *description)
+		})
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("TODO_PROFILE", "")
+
+	testTable := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "no command", args: []string{}, expectedError: "no command given"},
+		{name: "unknown command", args: []string{"tasks"}, expectedError: `unknown command "tasks"`},
+		{name: "no subcommand", args: []string{"lists"}, expectedError: "no subcommand given"},
+		{name: "unknown subcommand", args: []string{"items", "mv"}, expectedError: `unknown subcommand "mv"`},
+		{name: "bad id", args: []string{"lists", "rm", "x"}, expectedError: `"x" is not an id`},
+		{name: "missing argument", args: []string{"items", "add", "1"},
+			expectedError: "items add takes 2 arguments, got 1"},
+		{name: "login without username", args: []string{"login"}, expectedError: "login needs --username"},
+		{name: "not logged in", args: []string{"--profile", "work", "lists", "ls"},
+			expectedError: `profile "work" is not logged in, run todo login`},
+		{name: "unknown profile", args: []string{"profiles", "use", "work"},
+			expectedError: `no profile "work", log in with todo --profile work login`},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := run(context.Background(), testCase.args)
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestOutput(t *testing.T) {
+	description := "weekly"
+	todoLists := []domain.TodoList{
+		{Id: 1, Title: "groceries", Description: &description, Version: 2},
+		{Id: 2, Title: "work", Pinned: true, Version: 1},
+	}
+	todoItems := []domain.TodoItem{
+		{Id: 5, Title: "milk", Done: true, Version: 3},
+	}
+
+	testTable := []struct {
+		name         string
+		args         []string
+		json         bool
+		mockBehavior func(m mocks)
+		expected     string
+	}{
+		{
+			name: "lists table",
+			args: []string{"lists", "ls"},
+			mockBehavior: func(m mocks) {
+				m.todoList.EXPECT().GetAll(1, false).Return(todoLists, nil)
+			},
+			expected: "ID  TITLE      DESCRIPTION  ARCHIVED  PINNED  VERSION\n" +
+				"1   groceries  weekly       false     false   2\n" +
+				"2   work       -            false     true    1\n",
+		},
+		{
+			name: "lists json",
+			args: []string{"lists", "ls", "--archived"},
+			json: true,
+			mockBehavior: func(m mocks) {
+				m.todoList.EXPECT().GetAll(1, true).Return(todoLists[1:], nil)
+			},
+			expected: `[
+  {
+    "id": 2,
+    "title": "work",
+    "description": null,
+    "archived": false,
+    "pinned": true,
+    "version": 1
+  }
+]
+`,
+		},
+		{
+			name: "items table",
+			args: []string{"items", "ls", "1"},
+			mockBehavior: func(m mocks) {
+				m.todoItem.EXPECT().GetAll(1, 1).Return(todoItems, nil)
+			},
+			expected: "ID  TITLE  DESCRIPTION  DONE  VERSION\n" +
+				"5   milk   -            true  3\n",
+		},
+		{
+			name: "items json",
+			args: []string{"items", "ls", "1"},
+			json: true,
+			mockBehavior: func(m mocks) {
+				m.todoItem.EXPECT().GetAll(1, 1).Return(todoItems, nil)
+			},
+			expected: `[
+  {
+    "id": 5,
+    "title": "milk",
+    "description": null,
+    "done": true,
+    "statusId": null,
+    "version": 3
+  }
+]
+`,
+		},
+		{
+			name: "created id",
+			args: []string{"items", "add", "1", "bread", "--description", "rye"},
+			json: true,
+			mockBehavior: func(m mocks) {
+				rye := "rye"
+				m.todoItem.EXPECT().Create(1, 1, domain.TodoItem{Title: "bread", Description: &rye}).Return(6, nil)
+			},
+			expected: "{\n  \"id\": 6\n}\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, out, m := newTestApp(t, testCase.json)
+			m.auth.EXPECT().ParseToken("access").Return(1, nil).AnyTimes()
+			testCase.mockBehavior(m)
+
+			err := commands[testCase.args[0]](context.Background(), a, testCase.args[1:])
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, out.String())
+		})
+	}
+}
+
+func TestConfig_roundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo", "config.json")
+
+	cfg, err := loadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, defaultProfile, cfg.Current)
+	assert.Empty(t, cfg.Profiles)
+
+	cfg.Current = "work"
+	cfg.Profiles["work"] = &profile{Server: "https://todo.example.com", Username: "ann",
+		AccessToken: "access", RefreshToken: "refresh"}
+	require.NoError(t, cfg.save())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := loadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
+
+func TestWithClient_savesRefreshedTokens(t *testing.T) {
+	a, _, m := newTestApp(t, false)
+
+	gomock.InOrder(
+		m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired),
+		m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+			Return(service.Tokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil),
+		m.auth.EXPECT().ParseToken("access2").Return(1, nil),
+	)
+	m.todoList.EXPECT().Delete(1, 2, nil).Return(nil)
+
+	require.NoError(t, commands["lists"](context.Background(), a, []string{"rm", "2"}))
+
+	loaded, err := loadConfig(a.config.path)
+	require.NoError(t, err)
+	assert.Equal(t, "access2", loaded.Profiles[defaultProfile].AccessToken)
+	assert.Equal(t, "refresh2", loaded.Profiles[defaultProfile].RefreshToken)
+}
+
+func TestLogin(t *testing.T) {
+	a, out, m := newTestApp(t, false)
+	server := a.config.Profiles[defaultProfile].Server
+	a.config.Profiles = map[string]*profile{}
+	a.profile = "work"
+	t.Setenv("TODO_PASSWORD", "secret")
+
+	m.auth.EXPECT().SignIn(gomock.Any(), "ann", "secret").
+		Return(service.Tokens{AccessToken: "access", RefreshToken: "refresh"}, nil)
+
+	err := login(context.Background(), a, []string{"--server", server, "--username", "ann"})
+	require.NoError(t, err)
+	assert.Equal(t, "Logged in to "+server+" as ann\n", out.String())
+
+	loaded, err := loadConfig(a.config.path)
+	require.NoError(t, err)
+	assert.Equal(t, "work", loaded.Current)
+	assert.Equal(t, &profile{Server: server, Username: "ann", AccessToken: "access", RefreshToken: "refresh"},
+		loaded.Profiles["work"])
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IvanMeln1k/go-todo-app/pkg/client"
+)
+
+// print writes v as JSON with --json, otherwise as a table of the header and
+// rows.
+func (a *app) print(v interface{}, header []string, rows [][]string) error {
+	if a.json {
+		encoder := json.NewEncoder(a.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func (a *app) printId(id int) error {
+	return a.print(map[string]int{"id": id}, []string{"ID"}, [][]string{{strconv.Itoa(id)}})
+}
+
+var (
+	listHeader = []string{"ID", "TITLE", "DESCRIPTION", "ARCHIVED", "PINNED", "VERSION"}
+	itemHeader = []string{"ID", "TITLE", "DESCRIPTION", "DONE", "VERSION"}
+)
+
+func listRow(todoList client.TodoList) []string {
+	return []string{
+		strconv.Itoa(todoList.Id),
+		todoList.Title,
+		text(todoList.Description),
+		strconv.FormatBool(todoList.Archived),
+		strconv.FormatBool(todoList.Pinned),
+		strconv.Itoa(todoList.Version),
+	}
+}
+
+func itemRow(todoItem client.TodoItem) []string {
+	return []string{
+		strconv.Itoa(todoItem.Id),
+		todoItem.Title,
+		text(todoItem.Description),
+		strconv.FormatBool(todoItem.Done),
+		strconv.Itoa(todoItem.Version),
+	}
+}
+
+func text(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
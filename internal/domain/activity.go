@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	ActivityListCreated  = "list.created"
+	ActivityListUpdated  = "list.updated"
+	ActivityListDeleted  = "list.deleted"
+	ActivityListRestored = "list.restored"
+
+	ActivityItemCreated   = "item.created"
+	ActivityItemUpdated   = "item.updated"
+	ActivityItemCompleted = "item.completed"
+	ActivityItemReopened  = "item.reopened"
+	ActivityItemDeleted   = "item.deleted"
+	ActivityItemRestored  = "item.restored"
+	ActivityItemMoved     = "item.moved"
+	ActivityItemAssigned  = "item.assigned"
+)
+
+type Change struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Changes maps a field name, as it appears in the API, to its old and new
+// values. It is stored as jsonb.
+type Changes map[string]Change
+
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *Changes) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, c)
+	case string:
+		return json.Unmarshal([]byte(src), c)
+	case nil:
+		*c = nil
+		return nil
+	}
+	return errors.New("unsupported type for changes")
+}
+
+type ActivityActor struct {
+	Id       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Username string `json:"username" db:"username"`
+}
+
+// Activity is an entry of the append-only log of mutations on lists and
+// items.
+type Activity struct {
+	Id        int           `json:"id" db:"id"`
+	ListId    int           `json:"listId" db:"list_id"`
+	ItemId    *int          `json:"itemId,omitempty" db:"item_id"`
+	Actor     ActivityActor `json:"actor" db:"actor"`
+	Action    string        `json:"action" db:"action"`
+	Changes   Changes       `json:"changes,omitempty" db:"changes"`
+	UndoOf    *int          `json:"undoOf,omitempty" db:"undo_of"`
+	Undone    bool          `json:"undone" db:"undone"`
+	CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+}
+
+// ActivityEvent announces a new entry of the activity log to the event
+// streams of all instances of the app.
+type ActivityEvent struct {
+	ActivityId int `json:"activityId"`
+	ListId     int `json:"listId"`
+}
+
+type ActivityPage struct {
+	Activities []Activity `json:"activities"`
+	Total      int        `json:"total"`
+	Limit      int        `json:"limit"`
+	Offset     int        `json:"offset"`
+}
+
+// UndoConflict describes a field that has changed since the operation being
+// undone.
+type UndoConflict struct {
+	Field    string      `json:"field"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+}
+
+// UndoConflictError is returned when the record has changed since the
+// operation, undoing it would overwrite somebody's work.
+type UndoConflictError struct {
+	ActivityId int
+	Conflicts  []UndoConflict
+}
+
+func (e *UndoConflictError) Error() string {
+	return fmt.Sprintf("activity %d conflicts with later changes", e.ActivityId)
+}
+
+func (e *UndoConflictError) Unwrap() error {
+	return ErrRecordChanged
+}
+
+// Details lists the conflicting fields for the client.
+func (e *UndoConflictError) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"activityId": e.ActivityId,
+		"conflicts":  e.Conflicts,
+	}
+}
+
+// intValue turns an optional id into a value comparable with ==, nil stays
+// nil so it is encoded as null.
+func intValue(value *int) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func stringValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// ListFields returns the fields of the list the activity log tracks.
+func ListFields(todoList TodoList) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       todoList.Title,
+		"description": stringValue(todoList.Description),
+		"archived":    todoList.Archived,
+		"pinned":      todoList.Pinned,
+	}
+}
+
+// ItemFields returns the fields of the item the activity log tracks.
+func ItemFields(todoItem TodoItem) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       todoItem.Title,
+		"description": stringValue(todoItem.Description),
+		"done":        todoItem.Done,
+		"statusId":    intValue(todoItem.StatusId),
+	}
+}
+
+func fieldChanges(before map[string]interface{}, after map[string]interface{}) Changes {
+	changes := make(Changes)
+	for field, old := range before {
+		if new := after[field]; old != new {
+			changes[field] = Change{Old: old, New: new}
+		}
+	}
+	return changes
+}
+
+func ListChanges(before TodoList, after TodoList) Changes {
+	return fieldChanges(ListFields(before), ListFields(after))
+}
+
+func ItemChanges(before TodoItem, after TodoItem) Changes {
+	return fieldChanges(ItemFields(before), ItemFields(after))
+}
+
+// ItemUpdateAction names an item update, done toggles get their own actions
+// so they are easy to spot in the feed.
+func ItemUpdateAction(changes Changes) string {
+	if change, ok := changes["done"]; ok {
+		if change.New == true {
+			return ActivityItemCompleted
+		}
+		return ActivityItemReopened
+	}
+	return ActivityItemUpdated
+}
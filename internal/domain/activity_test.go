@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemChanges(t *testing.T) {
+	todoStatusId, doneStatusId := 1, 2
+
+	testTable := []struct {
+		name            string
+		before          TodoItem
+		after           TodoItem
+		expectedChanges Changes
+		expectedAction  string
+	}{
+		{
+			name:   "title",
+			before: TodoItem{Id: 1, Title: "old"},
+			after:  TodoItem{Id: 1, Title: "new"},
+			expectedChanges: Changes{
+				"title": {Old: "old", New: "new"},
+			},
+			expectedAction: ActivityItemUpdated,
+		},
+		{
+			name:   "completed",
+			before: TodoItem{Id: 1, Title: "item", StatusId: &todoStatusId},
+			after:  TodoItem{Id: 1, Title: "item", Done: true, StatusId: &doneStatusId},
+			expectedChanges: Changes{
+				"done":     {Old: false, New: true},
+				"statusId": {Old: 1, New: 2},
+			},
+			expectedAction: ActivityItemCompleted,
+		},
+		{
+			name:   "reopened",
+			before: TodoItem{Id: 1, Title: "item", Done: true},
+			after:  TodoItem{Id: 1, Title: "item"},
+			expectedChanges: Changes{
+				"done": {Old: true, New: false},
+			},
+			expectedAction: ActivityItemReopened,
+		},
+		{
+			name:            "no changes",
+			before:          TodoItem{Id: 1, Title: "item", StatusId: &todoStatusId},
+			after:           TodoItem{Id: 1, Title: "item", StatusId: &todoStatusId},
+			expectedChanges: Changes{},
+			expectedAction:  ActivityItemUpdated,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			changes := ItemChanges(testCase.before, testCase.after)
+			assert.Equal(t, testCase.expectedChanges, changes)
+			assert.Equal(t, testCase.expectedAction, ItemUpdateAction(changes))
+		})
+	}
+}
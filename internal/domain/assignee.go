@@ -0,0 +1,16 @@
+package domain
+
+type Assignee struct {
+	Id       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Username string `json:"username" db:"username"`
+}
+
+type TodoItemAssignees struct {
+	UserIds []int `json:"userIds"`
+}
+
+type AssignedTodoItem struct {
+	TodoItem
+	ListId int `json:"listId" db:"list_id"`
+}
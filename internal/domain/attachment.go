@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Attachment is a file uploaded to a todo item. The content itself lives in
+// the blob store under StorageKey.
+type Attachment struct {
+	Id          int       `json:"id" db:"id"`
+	ItemId      int       `json:"itemId" db:"item_id"`
+	UserId      int       `json:"userId" db:"user_id"`
+	Name        string    `json:"name" db:"name"`
+	Size        int64     `json:"size" db:"size"`
+	ContentType string    `json:"contentType" db:"content_type"`
+	Checksum    string    `json:"checksum" db:"checksum"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type CommentAuthor struct {
+	Id       int    `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Username string `json:"username" db:"username"`
+}
+
+// Comment is a note on a todo item. Body holds markdown source, rendering is
+// left to the clients.
+type Comment struct {
+	Id        int           `json:"id" db:"id"`
+	ItemId    int           `json:"itemId" db:"item_id"`
+	Author    CommentAuthor `json:"author" db:"author"`
+	Body      string        `json:"body" db:"body"`
+	CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time     `json:"updatedAt" db:"updated_at"`
+}
+
+type CommentInput struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type CommentsPage struct {
+	Comments []Comment `json:"comments"`
+	Total    int       `json:"total"`
+	Limit    int       `json:"limit"`
+	Offset   int       `json:"offset"`
+}
@@ -0,0 +1,122 @@
+package domain
+
+import "errors"
+
+// ErrorKind tells how an error is reported to clients.
+type ErrorKind int
+
+const (
+	KindNotFound ErrorKind = iota + 1
+	KindConflict
+	KindForbidden
+	KindValidation
+	KindUnauthorized
+	KindPreconditionFailed
+	KindTooLarge
+	KindUnsupportedMediaType
+)
+
+// Error is an error reported to API clients. Code is stable and meant for
+// clients to switch on, Message is meant for people.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	// Fields lists the invalid fields of a validation error.
+	Fields []FieldError
+}
+
+// FieldError describes an invalid field. Field is the JSON path of the
+// field and Rule the rule it broke, with its parameter if it has one.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors with the same code, so a validation error with fields
+// still matches the error it was made from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithFields returns a copy of the error listing the invalid fields.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	err := *e
+	err.Fields = fields
+	return &err
+}
+
+func NewNotFoundError(code string, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func NewConflictError(code string, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func NewForbiddenError(code string, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func NewValidationError(code string, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func NewUnauthorizedError(code string, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func NewPreconditionFailedError(code string, message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Message: message}
+}
+
+func NewTooLargeError(code string, message string) *Error {
+	return &Error{Kind: KindTooLarge, Code: code, Message: message}
+}
+
+func NewUnsupportedMediaTypeError(code string, message string) *Error {
+	return &Error{Kind: KindUnsupportedMediaType, Code: code, Message: message}
+}
+
+// ErrValidation is the error for invalid input, its copies list the invalid
+// fields.
+var ErrValidation = NewValidationError("validation_failed", "Validation failed")
+
+var (
+	ErrListNotFound       = NewNotFoundError("list_not_found", "List not found")
+	ErrItemNotFound       = NewNotFoundError("item_not_found", "Item not found")
+	ErrStatusNotFound     = NewNotFoundError("status_not_found", "Status not found")
+	ErrTemplateNotFound   = NewNotFoundError("template_not_found", "Template not found")
+	ErrCommentNotFound    = NewNotFoundError("comment_not_found", "Comment not found")
+	ErrAttachmentNotFound = NewNotFoundError("attachment_not_found", "Attachment not found")
+	ErrActivityNotFound   = NewNotFoundError("activity_not_found", "Activity not found")
+	ErrUserNotFound       = NewNotFoundError("user_not_found", "User not found")
+	ErrWebhookNotFound    = NewNotFoundError("webhook_not_found", "Webhook not found")
+	ErrDeliveryNotFound   = NewNotFoundError("delivery_not_found", "Delivery not found")
+)
+
+var (
+	ErrAssigneeHasNoAccess = NewValidationError("assignee_has_no_access", "Assignee has no access to the list")
+	ErrInvalidSyncToken    = NewValidationError("invalid_sync_token", "Invalid sync token")
+)
+
+var (
+	ErrListArchived  = NewConflictError("list_archived", "List is archived")
+	ErrClientIdTaken = NewConflictError("client_id_taken", "Client id is already taken")
+	ErrNotUndoable   = NewConflictError("not_undoable", "Operation can't be undone")
+	ErrAlreadyUndone = NewConflictError("already_undone", "Operation is already undone")
+	ErrRecordChanged = NewConflictError("record_changed", "Record has changed since the operation")
+)
+
+// IsNotFound reports whether err is a not found error of any kind of record.
+func IsNotFound(err error) bool {
+	var domainErr *Error
+	return errors.As(err, &domainErr) && domainErr.Kind == KindNotFound
+}
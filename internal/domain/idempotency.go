@@ -0,0 +1,16 @@
+package domain
+
+// IdempotentResponse is the stored outcome of a request sent with an
+// Idempotency-Key header. A record without a status code belongs to a request
+// that is still being processed.
+type IdempotentResponse struct {
+	// Fingerprint identifies the request the key was first used with.
+	Fingerprint string            `json:"fingerprint"`
+	StatusCode  int               `json:"statusCode,omitempty"`
+	Header      map[string]string `json:"header,omitempty"`
+	Body        []byte            `json:"body,omitempty"`
+}
+
+func (r IdempotentResponse) Completed() bool {
+	return r.StatusCode != 0
+}
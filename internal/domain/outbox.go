@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AggregateList     = "list"
+	AggregateItem     = "item"
+	AggregateActivity = "activity"
+)
+
+// The events of records deleted from the trash for good, which leave no
+// activity behind.
+const (
+	EventListPurged = "list.purged"
+	EventItemPurged = "item.purged"
+)
+
+// OutboxEvent is a domain event. It is written to the outbox in the
+// transaction of the change it describes and relayed to the event bus once
+// committed. Type is one of the activity actions or a purge event.
+type OutboxEvent struct {
+	Id            int             `db:"id"`
+	AggregateType string          `db:"aggregate_type"`
+	AggregateId   int             `db:"aggregate_id"`
+	Type          string          `db:"event_type"`
+	Payload       json.RawMessage `db:"-"`
+	CreatedAt     time.Time       `db:"created_at"`
+}
+
+// ListEvent is the payload of the events of lists.
+type ListEvent struct {
+	ListId int `json:"listId"`
+}
+
+// ItemEvent is the payload of the events of items, ListId is the list the
+// item is in after the change.
+type ItemEvent struct {
+	ListId int `json:"listId"`
+	ItemId int `json:"itemId"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "errors"
+
+type Status struct {
+	Id       int    `json:"id" db:"id"`
+	ListId   int    `json:"listId" db:"list_id"`
+	Name     string `json:"name" db:"name" validate:"required,title"`
+	Position int    `json:"position" db:"position"`
+	IsDone   bool   `json:"isDone" db:"is_done"`
+}
+
+// DefaultStatuses are the columns every new list starts with.
+var DefaultStatuses = []Status{
+	{Name: "todo", Position: 1},
+	{Name: "in progress", Position: 2},
+	{Name: "blocked", Position: 3},
+	{Name: "review", Position: 4},
+	{Name: "done", Position: 5, IsDone: true},
+}
+
+type UpdateStatus struct {
+	Name     *string `json:"name" validate:"title"`
+	Position *int    `json:"position"`
+	IsDone   *bool   `json:"isDone"`
+}
+
+func (i UpdateStatus) Validate() error {
+	if i.Name == nil && i.Position == nil && i.IsDone == nil {
+		return errors.New("update struct has no values")
+	}
+	return nil
+}
+
+type TodoItemStatus struct {
+	StatusId int `json:"statusId" validate:"required"`
+}
+
+type BoardColumn struct {
+	Status
+	Items []TodoItem `json:"items"`
+}
+
+type Board struct {
+	TodoList   TodoList      `json:"todoList"`
+	Columns    []BoardColumn `json:"columns"`
+	Unassigned []TodoItem    `json:"unassigned,omitempty"`
+}
@@ -0,0 +1,113 @@
+package domain
+
+import "errors"
+
+const (
+	SyncList = "list"
+	SyncItem = "item"
+
+	SyncUpsert = "upsert"
+	SyncDelete = "delete"
+
+	// SyncApplied reports a change the server made, or had already made
+	// when the change is sent again.
+	SyncApplied = "applied"
+	// SyncConflict reports a change made on an outdated record. The server
+	// keeps its version and returns it.
+	SyncConflict = "conflict"
+	// SyncRejected reports a change the server refused, like one on a
+	// record the user cannot access.
+	SyncRejected = "rejected"
+)
+
+type SyncedTodoItem struct {
+	TodoItem
+	ListId int `json:"listId" db:"list_id"`
+}
+
+// SyncTombstone is a record deleted for good, or one the user lost access
+// to.
+type SyncTombstone struct {
+	Type string `json:"type" db:"record_type"`
+	Id   int    `json:"id" db:"record_id"`
+}
+
+// SyncChanges holds the lists and items that changed since a sync token,
+// and the token to get the next changes with. Records in the trash come
+// with deletedAt set, and the items of a list in the trash are left out.
+// The items of a list are sent again when it changes. The changes come in
+// pages, HasMore tells that the token continues with the next page.
+type SyncChanges struct {
+	TodoLists []TodoList       `json:"todoLists"`
+	TodoItems []SyncedTodoItem `json:"todoItems"`
+	Deleted   []SyncTombstone  `json:"deleted"`
+	Token     string           `json:"token"`
+	HasMore   bool             `json:"hasMore"`
+}
+
+// SyncDeleted is the part of the changes holding the tombstones.
+const SyncDeleted = "deleted"
+
+// SyncCursor is where a sync reads the changes from. Since is the snapshot
+// of the last sync, empty for a first sync. The changes are read in pages,
+// the lists first, then the items, then the tombstones, each in id order.
+// Until is the snapshot the first page was read with, the next sync starts
+// from it. Records is the part of the changes being read and RecordType and
+// RecordId the last record of it read.
+type SyncCursor struct {
+	Since      string `json:"since,omitempty"`
+	Until      string `json:"until,omitempty"`
+	Records    string `json:"records,omitempty"`
+	RecordType string `json:"recordType,omitempty"`
+	RecordId   int    `json:"recordId,omitempty"`
+}
+
+// SyncChange is a change made on a client. A record is referred to by its
+// id or by the client id it was created with, an upsert of a record the
+// server does not know yet creates it with that client id. BaseVersion is
+// the version the change was made on, without it the change overwrites the
+// record.
+type SyncChange struct {
+	Type         string  `json:"type" validate:"required,oneof=list item"`
+	Op           string  `json:"op" validate:"required,oneof=upsert delete"`
+	Id           *int    `json:"id"`
+	ClientId     *string `json:"clientId" validate:"omitempty,uuid"`
+	ListId       *int    `json:"listId"`
+	ListClientId *string `json:"listClientId" validate:"omitempty,uuid"`
+	BaseVersion  *int    `json:"baseVersion"`
+	Title        *string `json:"title" validate:"title"`
+	Description  *string `json:"description" validate:"varchar"`
+	Done         bool    `json:"done"`
+}
+
+func (c SyncChange) Validate() error {
+	if c.Id == nil && c.ClientId == nil {
+		return errors.New(c.Op + " change has no id or clientId")
+	}
+	if c.Op == SyncUpsert {
+		if c.Title == nil {
+			return errors.New("upsert change has no title")
+		}
+		if c.Type == SyncItem && c.Id == nil && c.ListId == nil && c.ListClientId == nil {
+			return errors.New("item upsert change has no listId or listClientId")
+		}
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	Since   string       `json:"since"`
+	Changes []SyncChange `json:"changes" validate:"max=500,dive"`
+}
+
+// SyncResult is the outcome of a change, with the record as the server has
+// it. Code tells why a change was rejected.
+type SyncResult struct {
+	Type     string          `json:"type"`
+	Id       int             `json:"id,omitempty"`
+	ClientId *string         `json:"clientId,omitempty"`
+	Status   string          `json:"status"`
+	Code     string          `json:"code,omitempty"`
+	TodoList *TodoList       `json:"todoList,omitempty"`
+	TodoItem *SyncedTodoItem `json:"todoItem,omitempty"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+type ListTemplate struct {
+	Id          int            `json:"id" db:"id"`
+	Title       string         `json:"title" db:"title"`
+	Description *string        `json:"description" db:"description"`
+	Items       []TemplateItem `json:"items,omitempty" db:"-"`
+}
+
+type TemplateItem struct {
+	Id          int     `json:"id" db:"id"`
+	Title       string  `json:"title" db:"title"`
+	Description *string `json:"description" db:"description"`
+}
+
+type CreateListTemplate struct {
+	Title *string `json:"title" validate:"title"`
+}
+
+type InstantiateListTemplate struct {
+	Title     *string           `json:"title" validate:"title"`
+	Variables map[string]string `json:"variables"`
+}
@@ -1,23 +1,38 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type TodoList struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" validate:"required" db:"title"`
-	Description string `json:"description" title:"description"`
+	Id          int        `json:"id" db:"id"`
+	Title       string     `json:"title" validate:"required,title" db:"title"`
+	Description *string    `json:"description" title:"description" validate:"varchar"`
+	Archived    bool       `json:"archived" db:"archived"`
+	Pinned      bool       `json:"pinned" db:"pinned"`
+	Version     int        `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	// ClientId is the id a client gave the record when it created it
+	// offline, see SyncChange.
+	ClientId *string `json:"clientId,omitempty" db:"client_id" validate:"omitempty,uuid"`
 }
 
-type UpdateTodoList struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
+// ReplaceTodoList holds the editable fields of a list, PUT replaces all of
+// them. Archiving and pinning have their own endpoints.
+type ReplaceTodoList struct {
+	Title       string  `json:"title" validate:"required,title"`
+	Description *string `json:"description" validate:"varchar"`
+	// Version makes the replacement conditional on the current version of
+	// the list, it comes from the If-Match header.
+	Version *int `json:"-"`
 }
 
-func (i UpdateTodoList) Validate() error {
-	if i.Title == nil && i.Description == nil {
-		return errors.New("update struct has no values")
+func (l TodoList) Replacement() ReplaceTodoList {
+	return ReplaceTodoList{
+		Title:       l.Title,
+		Description: l.Description,
 	}
-	return nil
 }
 
 type UsersList struct {
@@ -27,12 +42,38 @@ type UsersList struct {
 }
 
 type TodoItem struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" db:"title" validate:"required"`
-	Description string `json:"description" db:"description"`
-	Done        bool   `done:"done" db:"done"`
+	Id          int        `json:"id" db:"id"`
+	Title       string     `json:"title" db:"title" validate:"required,title"`
+	Description *string    `json:"description" db:"description" validate:"varchar"`
+	Done        bool       `done:"done" db:"done"`
+	StatusId    *int       `json:"statusId" db:"status_id"`
+	Version     int        `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	// ClientId is the id a client gave the record when it created it
+	// offline, see SyncChange.
+	ClientId *string `json:"clientId,omitempty" db:"client_id" validate:"omitempty,uuid"`
+}
+
+// ReplaceTodoItem holds the editable fields of an item, PUT replaces all of
+// them. Statuses and the list are changed through their own endpoints.
+type ReplaceTodoItem struct {
+	Title       string  `json:"title" validate:"required,title"`
+	Description *string `json:"description" validate:"varchar"`
+	Done        bool    `json:"done"`
+	// Version makes the replacement conditional on the current version of
+	// the item, it comes from the If-Match header.
+	Version *int `json:"-"`
+}
+
+func (i TodoItem) Replacement() ReplaceTodoItem {
+	return ReplaceTodoItem{
+		Title:       i.Title,
+		Description: i.Description,
+		Done:        i.Done,
+	}
 }
 
+// UpdateTodoItem is a partial update of an item, only the set fields change.
 type UpdateTodoItem struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
@@ -51,3 +92,75 @@ type ListsItem struct {
 	ListId int
 	ItemId int
 }
+
+type TodoItemDestination struct {
+	ListId int `json:"listId" validate:"required"`
+}
+
+const (
+	BulkOperationCreate   = "create"
+	BulkOperationUpdate   = "update"
+	BulkOperationDelete   = "delete"
+	BulkOperationComplete = "complete"
+)
+
+type BulkItemOperation struct {
+	Op          string  `json:"op" validate:"required,oneof=create update delete complete"`
+	Id          int     `json:"id"`
+	Title       *string `json:"title" validate:"title"`
+	Description *string `json:"description" validate:"varchar"`
+	Done        *bool   `json:"done"`
+}
+
+func (o BulkItemOperation) Validate() error {
+	switch o.Op {
+	case BulkOperationCreate:
+		if o.Title == nil || *o.Title == "" {
+			return errors.New("create operation has no title")
+		}
+	case BulkOperationUpdate:
+		if o.Id == 0 {
+			return errors.New("update operation has no id")
+		}
+		return o.UpdateTodoItem().Validate()
+	case BulkOperationDelete, BulkOperationComplete:
+		if o.Id == 0 {
+			return errors.New(o.Op + " operation has no id")
+		}
+	}
+	return nil
+}
+
+func (o BulkItemOperation) TodoItem() TodoItem {
+	var todoItem TodoItem
+	if o.Title != nil {
+		todoItem.Title = *o.Title
+	}
+	todoItem.Description = o.Description
+	if o.Done != nil {
+		todoItem.Done = *o.Done
+	}
+	return todoItem
+}
+
+func (o BulkItemOperation) UpdateTodoItem() UpdateTodoItem {
+	return UpdateTodoItem{
+		Title:       o.Title,
+		Description: o.Description,
+		Done:        o.Done,
+	}
+}
+
+type BulkItemOperations struct {
+	Operations []BulkItemOperation `json:"operations" validate:"required,min=1,dive"`
+}
+
+type BulkItemResult struct {
+	Op string `json:"op"`
+	Id int    `json:"id"`
+}
+
+type DuplicateTodoList struct {
+	Title     *string `json:"title" validate:"title"`
+	ResetDone bool    `json:"resetDone"`
+}
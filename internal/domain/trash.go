@@ -0,0 +1,11 @@
+package domain
+
+type TrashedTodoItem struct {
+	TodoItem
+	ListId int `json:"listId" db:"list_id"`
+}
+
+type Trash struct {
+	TodoLists []TodoList        `json:"todoLists"`
+	TodoItems []TrashedTodoItem `json:"todoItems"`
+}
@@ -2,7 +2,7 @@ package domain
 
 type User struct {
 	Id       int    `json:"-"`
-	Name     string `json:"name" validate:"required"`
-	Username string `json:"username" validate:"required"`
+	Name     string `json:"name" validate:"required,varchar"`
+	Username string `json:"username" validate:"required,varchar"`
 	Password string `json:"password" validate:"required" db:"password_hash"`
 }
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// Webhook posts the activity on a list to a URL. Secret signs the payloads,
+// it is only shown when the webhook is created.
+type Webhook struct {
+	Id        int       `json:"id" db:"id"`
+	ListId    int       `json:"listId" db:"list_id"`
+	URL       string    `json:"url" db:"url" validate:"required,httpurl"`
+	Secret    string    `json:"secret,omitempty" db:"secret"`
+	Events    []string  `json:"events" db:"-" validate:"required,min=1,dive,oneof=list.updated list.deleted list.restored item.created item.updated item.completed item.reopened item.deleted item.restored item.moved item.assigned"`
+	Active    bool      `json:"active" db:"active"`
+	Failures  int       `json:"failures" db:"failures"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// UpdateWebhook replaces the settings of a webhook. Activating it again
+// resets its failures.
+type UpdateWebhook struct {
+	URL    string   `json:"url" validate:"required,httpurl"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=list.updated list.deleted list.restored item.created item.updated item.completed item.reopened item.deleted item.restored item.moved item.assigned"`
+	Active bool     `json:"active"`
+}
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is an entry of the delivery log of a webhook, it is also
+// the queue of deliveries to send.
+type WebhookDelivery struct {
+	Id            int        `json:"id" db:"id"`
+	WebhookId     int        `json:"webhookId" db:"webhook_id"`
+	ActivityId    int        `json:"activityId" db:"activity_id"`
+	Event         string     `json:"event" db:"event"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	ResponseCode  *int       `json:"responseCode" db:"response_code"`
+	Error         *string    `json:"error" db:"error"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" db:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty" db:"delivered_at"`
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int               `json:"total"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+}
+
+// PendingDelivery is a delivery claimed for sending, with the webhook it
+// goes to.
+type PendingDelivery struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// DeliveryAttempt is the outcome of sending a delivery. NextAttemptAt is
+// nil when a failed delivery is not retried.
+type DeliveryAttempt struct {
+	DeliveryId    int
+	WebhookId     int
+	Succeeded     bool
+	ResponseCode  *int
+	Error         *string
+	NextAttemptAt *time.Time
+}
+
+// WebhookPayload is the body posted to webhooks.
+type WebhookPayload struct {
+	DeliveryId int      `json:"deliveryId"`
+	WebhookId  int      `json:"webhookId"`
+	Event      string   `json:"event"`
+	Activity   Activity `json:"activity"`
+}
@@ -0,0 +1,154 @@
+// Package graph serves a GraphQL API over the lists and items services.
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/sirupsen/logrus"
+)
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Caller is the user a request is executed for. Validate checks mutation
+// inputs and returns a domain validation error listing the invalid fields.
+type Caller struct {
+	UserId   int
+	Validate func(input interface{}) error
+}
+
+type Executor struct {
+	schema   graphql.Schema
+	services *service.Service
+	limits   Limits
+}
+
+// NewExecutor builds the schema over the services. The schema is static, so
+// an error building it is a bug and panics.
+func NewExecutor(services *service.Service, limits Limits) *Executor {
+	schema, err := newSchema(services)
+	if err != nil {
+		panic(err)
+	}
+	return &Executor{schema: schema, services: services, limits: limits}
+}
+
+// Execute runs a query or a mutation. Problems with the request, like a
+// query over the limits, are reported in the errors of the result.
+func (e *Executor) Execute(ctx context.Context, caller Caller, request Request) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&e.schema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := e.limits.check(e.schema, document, request.OperationName); err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{err.format()}}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(ctx, sessionKey{}, newSession(caller, e.services)),
+	})
+}
+
+type sessionKey struct{}
+
+// session is the state of a request: the caller and the loaders batching
+// the fields of its query.
+type session struct {
+	Caller
+	items     *loader[[]domain.TodoItem]
+	members   *loader[[]domain.Assignee]
+	assignees *loader[[]domain.Assignee]
+}
+
+func newSession(caller Caller, services *service.Service) *session {
+	return &session{
+		Caller: caller,
+		items: newLoader(func(todoListIds []int) (map[int][]domain.TodoItem, error) {
+			return services.TodoItem.GetAllByLists(caller.UserId, todoListIds)
+		}),
+		members: newLoader(func(todoListIds []int) (map[int][]domain.Assignee, error) {
+			return services.Assignee.GetListMembersByLists(caller.UserId, todoListIds)
+		}),
+		assignees: newLoader(func(todoItemIds []int) (map[int][]domain.Assignee, error) {
+			return services.Assignee.GetAllByItems(caller.UserId, todoItemIds)
+		}),
+	}
+}
+
+func sessionFrom(ctx context.Context) *session {
+	return ctx.Value(sessionKey{}).(*session)
+}
+
+// queryError is an error with the code the REST API reports for it, in its
+// extensions.
+type queryError struct {
+	code    string
+	message string
+	fields  []domain.FieldError
+}
+
+func newQueryError(code string, message string) *queryError {
+	return &queryError{code: code, message: message}
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if len(e.fields) > 0 {
+		extensions["errors"] = e.fields
+	}
+	return extensions
+}
+
+func (e *queryError) withDetail(detail string) *queryError {
+	err := *e
+	err.message += ": " + detail
+	return &err
+}
+
+// format reports an error found before the execution, it has no location in
+// the query.
+func (e *queryError) format() gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:    e.message,
+		Locations:  []location.SourceLocation{},
+		Extensions: e.Extensions(),
+	}
+}
+
+// resolverError reports a domain error with its code. Other errors are
+// logged and reported without their details.
+func resolverError(err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return &queryError{code: domainErr.Code, message: domainErr.Message, fields: domainErr.Fields}
+	}
+	logrus.Error(err)
+	return newQueryError("internal_error", "Internal server error")
+}
@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor_batchesFields(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	todoList := mock_service.NewMockTodoList(c)
+	todoItem := mock_service.NewMockTodoItem(c)
+	assignee := mock_service.NewMockAssignee(c)
+
+	todoList.EXPECT().GetAll(1, false).Return([]domain.TodoList{{Id: 1, Title: "a"}, {Id: 2, Title: "b"}}, nil)
+	todoItem.EXPECT().GetAllByLists(1, []int{1, 2}).Return(map[int][]domain.TodoItem{
+		1: {{Id: 10, Title: "x", Done: true}, {Id: 11, Title: "y"}},
+		2: {},
+	}, nil)
+	assignee.EXPECT().GetAllByItems(1, []int{10, 11}).Return(map[int][]domain.Assignee{
+		10: {{Id: 3, Name: "Ann", Username: "ann"}},
+		11: {},
+	}, nil)
+
+	executor := NewExecutor(&service.Service{TodoList: todoList, TodoItem: todoItem, Assignee: assignee}, DefaultLimits)
+	result := executor.Execute(context.Background(), Caller{UserId: 1}, Request{
+		Query: `{ lists { id doneCount items { title assignees { username } } } }`,
+	})
+
+	body, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"lists":[`+
+		`{"id":1,"doneCount":1,"items":[{"title":"x","assignees":[{"username":"ann"}]},{"title":"y","assignees":[]}]},`+
+		`{"id":2,"doneCount":0,"items":[]}]}}`, string(body))
+}
+
+func TestExecutor_errors(t *testing.T) {
+	testTable := []struct {
+		name           string
+		query          string
+		limits         Limits
+		mockBehavior   func(s *mock_service.MockTodoList)
+		expectedErrors string
+	}{
+		{
+			name:           "too deep",
+			query:          `{ lists { items { assignees { id } } } }`,
+			limits:         Limits{MaxDepth: 3, MaxComplexity: 5000},
+			mockBehavior:   func(s *mock_service.MockTodoList) {},
+			expectedErrors: `[{"message":"Query is nested too deep: depth 4 exceeds 3","locations":[],"extensions":{"code":"query_too_deep"}}]`,
+		},
+		{
+			name:           "too complex",
+			query:          `{ lists { items { id title } } }`,
+			limits:         Limits{MaxDepth: 8, MaxComplexity: 100},
+			mockBehavior:   func(s *mock_service.MockTodoList) {},
+			expectedErrors: `[{"message":"Query is too complex: complexity 211 exceeds 100","locations":[],"extensions":{"code":"query_too_complex"}}]`,
+		},
+		{
+			// The introspection query of GraphQL tools is deeper and wider
+			// than MaxDepth and MaxComplexity allow for data.
+			name: "introspection",
+			query: `{ __schema { types { name fields { name args { name type { ...TypeRef } } type { ...TypeRef } } } } }
+			fragment TypeRef on __Type { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name }}}}}}} }`,
+			limits:         DefaultLimits,
+			mockBehavior:   func(s *mock_service.MockTodoList) {},
+			expectedErrors: `null`,
+		},
+		{
+			name:           "introspection too deep",
+			query:          `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { ofType { ofType { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } } } } } } } } }`,
+			limits:         DefaultLimits,
+			mockBehavior:   func(s *mock_service.MockTodoList) {},
+			expectedErrors: `[{"message":"Query is nested too deep: introspection depth 17 exceeds 15","locations":[],"extensions":{"code":"query_too_deep"}}]`,
+		},
+		{
+			name:   "not found",
+			query:  `{ list(id: 5) { title } }`,
+			limits: DefaultLimits,
+			mockBehavior: func(s *mock_service.MockTodoList) {
+				s.EXPECT().GetById(1, 5).Return(domain.TodoList{}, domain.ErrListNotFound)
+			},
+			expectedErrors: `[{"message":"List not found","locations":[{"line":1,"column":3}],"path":["list"],` +
+				`"extensions":{"code":"list_not_found"}}]`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoList := mock_service.NewMockTodoList(c)
+			testCase.mockBehavior(todoList)
+
+			executor := NewExecutor(&service.Service{TodoList: todoList}, testCase.limits)
+			result := executor.Execute(context.Background(), Caller{UserId: 1}, Request{Query: testCase.query})
+
+			errs, err := json.Marshal(result.Errors)
+			assert.NoError(t, err)
+			assert.JSONEq(t, testCase.expectedErrors, string(errs))
+		})
+	}
+}
+
+func TestExecutor_updateListRetries(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	todoList := mock_service.NewMockTodoList(c)
+	version1, version2 := 1, 2
+
+	// The list changes between reading and writing it, the update is tried
+	// again on the list read next.
+	gomock.InOrder(
+		todoList.EXPECT().GetById(1, 5).Return(domain.TodoList{Id: 5, Title: "a", Version: 1}, nil),
+		todoList.EXPECT().Replace(1, 5, domain.ReplaceTodoList{Title: "b", Version: &version1}).
+			Return(domain.TodoList{}, service.ErrVersionMismatch),
+		todoList.EXPECT().GetById(1, 5).Return(domain.TodoList{Id: 5, Title: "c", Version: 2}, nil),
+		todoList.EXPECT().Replace(1, 5, domain.ReplaceTodoList{Title: "b", Version: &version2}).
+			Return(domain.TodoList{Id: 5, Title: "b", Version: 3}, nil),
+	)
+
+	validate := func(input interface{}) error { return nil }
+	executor := NewExecutor(&service.Service{TodoList: todoList}, DefaultLimits)
+	result := executor.Execute(context.Background(), Caller{UserId: 1, Validate: validate}, Request{
+		Query: `mutation { updateList(id: 5, title: "b") { title version } }`,
+	})
+
+	body, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"updateList":{"title":"b","version":3}}}`, string(body))
+}
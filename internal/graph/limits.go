@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the cost of a query, it is rejected before it runs if it is
+// nested deeper or is more complex than allowed.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+const (
+	// listFactor is the number of elements a list field is assumed to have
+	// when the complexity of its selections is counted.
+	listFactor = 10
+	// maxIntrospectionDepth bounds the nesting under __schema and __type. The
+	// introspection query of GraphQL tools nests 13 deep.
+	maxIntrospectionDepth = 15
+)
+
+// introspectionFields are the fields every type or the query type has
+// without the schema defining them.
+var introspectionFields = map[string]*graphql.FieldDefinition{
+	"__schema":   graphql.SchemaMetaFieldDef,
+	"__type":     graphql.TypeMetaFieldDef,
+	"__typename": graphql.TypeNameMetaFieldDef,
+}
+
+var (
+	errQueryTooDeep    = newQueryError("query_too_deep", "Query is nested too deep")
+	errQueryTooComplex = newQueryError("query_too_complex", "Query is too complex")
+)
+
+// costWalker measures the selections of an operation. Every field costs one,
+// plus the cost of its selections, times listFactor for list fields. Fields
+// the schema does not define cost one. The selections of __schema and __type
+// read the schema only: they are nested deeper than data queries, so their
+// depth is checked against maxIntrospectionDepth instead, and their lists
+// are not multiplied.
+type costWalker struct {
+	fragments map[string]*ast.FragmentDefinition
+	// visiting guards against fragment cycles the validation has not
+	// rejected yet.
+	visiting map[string]bool
+	// introspection is set while the selections of __schema or __type are
+	// measured, introspectionDepth is the deepest of them.
+	introspection      bool
+	introspectionDepth int
+}
+
+func (l Limits) check(schema graphql.Schema, document *ast.Document, operationName string) *queryError {
+	walker := &costWalker{fragments: map[string]*ast.FragmentDefinition{}, visiting: map[string]bool{}}
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			walker.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || definition.Name != nil && definition.Name.Value == operationName {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	depth, complexity := walker.selections(root, operation.SelectionSet)
+	if depth > l.MaxDepth {
+		return errQueryTooDeep.withDetail(fmt.Sprintf("depth %d exceeds %d", depth, l.MaxDepth))
+	}
+	if walker.introspectionDepth > maxIntrospectionDepth {
+		return errQueryTooDeep.withDetail(fmt.Sprintf("introspection depth %d exceeds %d",
+			walker.introspectionDepth, maxIntrospectionDepth))
+	}
+	if complexity > l.MaxComplexity {
+		return errQueryTooComplex.withDetail(fmt.Sprintf("complexity %d exceeds %d", complexity, l.MaxComplexity))
+	}
+	return nil
+}
+
+// selections returns the depth and the complexity of a selection set on a
+// type.
+func (w *costWalker) selections(parent graphql.Type, set *ast.SelectionSet) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	depth, complexity := 0, 0
+	for _, selection := range set.Selections {
+		d, c := 0, 0
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = w.field(parent, selection)
+		case *ast.InlineFragment:
+			d, c = w.selections(parent, selection.SelectionSet)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			if fragment, ok := w.fragments[name]; ok && !w.visiting[name] {
+				w.visiting[name] = true
+				d, c = w.selections(parent, fragment.SelectionSet)
+				delete(w.visiting, name)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (w *costWalker) field(parent graphql.Type, field *ast.Field) (int, int) {
+	object, ok := parent.(interface {
+		Fields() graphql.FieldDefinitionMap
+	})
+	if !ok {
+		return 1, 1
+	}
+	name := field.Name.Value
+	definition, ok := object.Fields()[name]
+	if !ok {
+		definition, ok = introspectionFields[name]
+	}
+	if !ok {
+		return 1, 1
+	}
+
+	fieldType, factor := definition.Type, 1
+	for {
+		switch t := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = t.OfType
+			continue
+		case *graphql.List:
+			fieldType, factor = t.OfType, factor*listFactor
+			continue
+		}
+		break
+	}
+
+	if w.introspection {
+		factor = 1
+	} else if name == "__schema" || name == "__type" {
+		w.introspection = true
+		depth, complexity := w.selections(fieldType, field.SelectionSet)
+		w.introspection = false
+		w.introspectionDepth = max(w.introspectionDepth, depth+1)
+		return 1, 1 + complexity
+	}
+
+	depth, complexity := w.selections(fieldType, field.SelectionSet)
+	return depth + 1, 1 + factor*complexity
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"slices"
+	"sync"
+)
+
+// loader batches loads by id. The executor resolves a level of the query
+// before it calls the thunks of that level, so the ids of all the fields of a
+// level are fetched with one call when the first thunk runs.
+type loader[V any] struct {
+	fetch func(ids []int) (map[int]V, error)
+
+	mu      sync.Mutex
+	pending []int
+	values  map[int]V
+	errs    map[int]error
+}
+
+func newLoader[V any](fetch func(ids []int) (map[int]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:  fetch,
+		values: map[int]V{},
+		errs:   map[int]error{},
+	}
+}
+
+// load queues an id and returns a thunk of its value.
+func (l *loader[V]) load(id int) func() (V, error) {
+	l.mu.Lock()
+	if _, ok := l.values[id]; !ok && !slices.Contains(l.pending, id) {
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			ids := l.pending
+			l.pending = nil
+
+			values, err := l.fetch(ids)
+			for _, id := range ids {
+				if err != nil {
+					l.errs[id] = err
+				} else {
+					l.values[id] = values[id]
+				}
+			}
+		}
+		return l.values[id], l.errs[id]
+	}
+}
+
+// thunk adapts a typed thunk to the signature the executor expects.
+func thunk[V any](load func() (V, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		return value, nil
+	}
+}
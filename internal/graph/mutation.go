@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/graphql-go/graphql"
+)
+
+// maxUpdateAttempts is the number of times an update without a version is
+// tried when the record changes between reading and writing it.
+const maxUpdateAttempts = 3
+
+// Updates change the arguments given, an empty description removes it. A
+// version makes them conditional, like If-Match does in the REST API.
+// Without one an update applies to the record it read and is retried when
+// the record changes meanwhile, like a PATCH without If-Match.
+func newMutation(services *service.Service) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createList": &graphql.Field{
+				Type: graphql.NewNonNull(listType),
+				Args: graphql.FieldConfigArgument{
+					"title":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"description": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					s := sessionFrom(p.Context)
+					todoList := domain.TodoList{Title: p.Args["title"].(string), Description: description(p.Args)}
+					if err := s.Validate(&todoList); err != nil {
+						return nil, err
+					}
+
+					todoListId, err := services.TodoList.Create(s.UserId, todoList)
+					if err != nil {
+						return nil, err
+					}
+					return services.TodoList.GetById(s.UserId, todoListId)
+				}),
+			},
+			"updateList": &graphql.Field{
+				Type: graphql.NewNonNull(listType),
+				Args: graphql.FieldConfigArgument{
+					"id":          idArgument,
+					"title":       &graphql.ArgumentConfig{Type: graphql.String},
+					"description": &graphql.ArgumentConfig{Type: graphql.String},
+					"version":     &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					s := sessionFrom(p.Context)
+					todoListId := p.Args["id"].(int)
+					ifVersion := version(p.Args)
+					for attempt := 1; ; attempt++ {
+						todoList, err := services.TodoList.GetById(s.UserId, todoListId)
+						if err != nil {
+							return nil, err
+						}
+
+						replacement := todoList.Replacement()
+						replacement.Version = &todoList.Version
+						if ifVersion != nil {
+							replacement.Version = ifVersion
+						}
+						if title, ok := p.Args["title"].(string); ok {
+							replacement.Title = title
+						}
+						if _, ok := p.Args["description"]; ok {
+							replacement.Description = description(p.Args)
+						}
+						if err = s.Validate(&replacement); err != nil {
+							return nil, err
+						}
+
+						todoList, err = services.TodoList.Replace(s.UserId, todoListId, replacement)
+						if errors.Is(err, service.ErrVersionMismatch) && ifVersion == nil && attempt < maxUpdateAttempts {
+							continue
+						}
+						return todoList, err
+					}
+				}),
+			},
+			"deleteList": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgument,
+					"version": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					err := services.TodoList.Delete(sessionFrom(p.Context).UserId, p.Args["id"].(int), version(p.Args))
+					return err == nil, err
+				}),
+			},
+			"createItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{
+					"listId":      idArgument,
+					"title":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"description": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					s := sessionFrom(p.Context)
+					todoItem := domain.TodoItem{Title: p.Args["title"].(string), Description: description(p.Args)}
+					if err := s.Validate(&todoItem); err != nil {
+						return nil, err
+					}
+
+					todoItemId, err := services.TodoItem.Create(s.UserId, p.Args["listId"].(int), todoItem)
+					if err != nil {
+						return nil, err
+					}
+					return services.TodoItem.GetById(s.UserId, todoItemId)
+				}),
+			},
+			"updateItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{
+					"id":          idArgument,
+					"title":       &graphql.ArgumentConfig{Type: graphql.String},
+					"description": &graphql.ArgumentConfig{Type: graphql.String},
+					"done":        &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"version":     &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					s := sessionFrom(p.Context)
+					todoItemId := p.Args["id"].(int)
+					ifVersion := version(p.Args)
+					for attempt := 1; ; attempt++ {
+						todoItem, err := services.TodoItem.GetById(s.UserId, todoItemId)
+						if err != nil {
+							return nil, err
+						}
+
+						replacement := todoItem.Replacement()
+						replacement.Version = &todoItem.Version
+						if ifVersion != nil {
+							replacement.Version = ifVersion
+						}
+						if title, ok := p.Args["title"].(string); ok {
+							replacement.Title = title
+						}
+						if _, ok := p.Args["description"]; ok {
+							replacement.Description = description(p.Args)
+						}
+						if done, ok := p.Args["done"].(bool); ok {
+							replacement.Done = done
+						}
+						if err = s.Validate(&replacement); err != nil {
+							return nil, err
+						}
+
+						todoItem, err = services.TodoItem.Replace(s.UserId, todoItemId, replacement)
+						if errors.Is(err, service.ErrVersionMismatch) && ifVersion == nil && attempt < maxUpdateAttempts {
+							continue
+						}
+						return todoItem, err
+					}
+				}),
+			},
+			"deleteItem": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id":      idArgument,
+					"version": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					err := services.TodoItem.Delete(sessionFrom(p.Context).UserId, p.Args["id"].(int), version(p.Args))
+					return err == nil, err
+				}),
+			},
+		},
+	})
+}
+
+func description(args map[string]interface{}) *string {
+	description, ok := args["description"].(string)
+	if !ok || description == "" {
+		return nil
+	}
+	return &description
+}
+
+func version(args map[string]interface{}) *int {
+	version, ok := args["version"].(int)
+	if !ok {
+		return nil
+	}
+	return &version
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/graphql-go/graphql"
+)
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"username": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var itemType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TodoItem",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.Field{Type: graphql.String},
+		"done":        &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"statusId":    &graphql.Field{Type: graphql.Int},
+		"version":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"assignees": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				todoItem := p.Source.(domain.TodoItem)
+				return thunk(sessionFrom(p.Context).assignees.load(todoItem.Id)), nil
+			},
+		},
+	},
+})
+
+var listType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TodoList",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.Field{Type: graphql.String},
+		"archived":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"pinned":      &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"version":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"items": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				todoList := p.Source.(domain.TodoList)
+				return thunk(sessionFrom(p.Context).items.load(todoList.Id)), nil
+			},
+		},
+		"itemCount": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return countItems(p, func(domain.TodoItem) bool { return true }), nil
+			},
+		},
+		"doneCount": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return countItems(p, func(todoItem domain.TodoItem) bool { return todoItem.Done }), nil
+			},
+		},
+		"members": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				todoList := p.Source.(domain.TodoList)
+				return thunk(sessionFrom(p.Context).members.load(todoList.Id)), nil
+			},
+		},
+	},
+})
+
+// countItems counts the items of a list that match, from the items loaded
+// for the list, so the counts cost no query of their own.
+func countItems(p graphql.ResolveParams, match func(todoItem domain.TodoItem) bool) func() (interface{}, error) {
+	load := sessionFrom(p.Context).items.load(p.Source.(domain.TodoList).Id)
+	return thunk(func() (int, error) {
+		todoItems, err := load()
+		count := 0
+		for _, todoItem := range todoItems {
+			if match(todoItem) {
+				count++
+			}
+		}
+		return count, err
+	})
+}
+
+func newSchema(services *service.Service) (graphql.Schema, error) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"lists": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(listType))),
+				Args: graphql.FieldConfigArgument{
+					"archived": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					return services.TodoList.GetAll(sessionFrom(p.Context).UserId, p.Args["archived"].(bool))
+				}),
+			},
+			"list": &graphql.Field{
+				Type: listType,
+				Args: graphql.FieldConfigArgument{"id": idArgument},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					return services.TodoList.GetById(sessionFrom(p.Context).UserId, p.Args["id"].(int))
+				}),
+			},
+			"item": &graphql.Field{
+				Type: itemType,
+				Args: graphql.FieldConfigArgument{"id": idArgument},
+				Resolve: resolve(func(p graphql.ResolveParams) (interface{}, error) {
+					return services.TodoItem.GetById(sessionFrom(p.Context).UserId, p.Args["id"].(int))
+				}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: newMutation(services),
+	})
+}
+
+var idArgument = &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}
+
+// resolve reports the errors of a resolver with their codes.
+func resolve(fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := fn(p)
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) getListActivity(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.services.Activity.GetByList(userId, todoListId, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, page)
+}
+
+func (h *Handler) getItemHistory(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.services.Activity.GetByItem(userId, todoItemId, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, page)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) getItemAssignees(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	assignees, err := h.services.Assignee.GetAll(userId, todoItemId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"assignees": assignees,
+	})
+}
+
+func (h *Handler) setItemAssignees(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	var itemAssignees domain.TodoItemAssignees
+	if err = c.Bind(&itemAssignees); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+
+	assignees, err := h.services.Assignee.Set(userId, todoItemId, itemAssignees.UserIds)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"assignees": assignees,
+	})
+}
+
+func (h *Handler) getAssignedItems(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItems, err := h.services.Assignee.GetAssigned(userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"todoItems": todoItems,
+	})
+}
+
+func (h *Handler) getListMembers(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	members, err := h.services.Assignee.GetListMembers(userId, todoListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"members": members,
+	})
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_setItemAssignees(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAssignee)
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"userIds":[1,3]}`,
+			mockBehavior: func(s *mock_service.MockAssignee) {
+				s.EXPECT().Set(1, 2, []int{1, 3}).Return([]domain.Assignee{
+					{Id: 1, Name: "Ivan", Username: "ivan"},
+					{Id: 3, Name: "Anna", Username: "anna"},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"assignees":[{"id":1,"name":"Ivan","username":"ivan"},` +
+				`{"id":3,"name":"Anna","username":"anna"}]}` + "\n",
+		},
+		{
+			name:      "assignee without access",
+			inputBody: `{"userIds":[4]}`,
+			mockBehavior: func(s *mock_service.MockAssignee) {
+				s.EXPECT().Set(1, 2, []int{4}).Return(nil, domain.ErrAssigneeHasNoAccess)
+			},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"assignee_has_no_access","detail":"Assignee has no access to the list",` +
+				`"instance":"/items/2/assignees","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name:      "list archived",
+			inputBody: `{"userIds":[]}`,
+			mockBehavior: func(s *mock_service.MockAssignee) {
+				s.EXPECT().Set(1, 2, []int{}).Return(nil, domain.ErrListArchived)
+			},
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"code":"list_archived","detail":"List is archived","instance":"/items/2/assignees",` +
+				`"status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			assignee := mock_service.NewMockAssignee(c)
+			testCase.mockBehavior(assignee)
+
+			handler := NewHandler(&service.Service{Assignee: assignee})
+
+			e := echo.New()
+			e.PUT("/items/:id/assignees", handler.setItemAssignees, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPut, "/items/2/assignees", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAssignedItems(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	assignee := mock_service.NewMockAssignee(c)
+	assignee.EXPECT().GetAssigned(1).Return([]domain.AssignedTodoItem{
+		{TodoItem: domain.TodoItem{Id: 5, Title: "item", Version: 2}, ListId: 2},
+	}, nil)
+
+	handler := NewHandler(&service.Service{Assignee: assignee})
+
+	e := echo.New()
+	e.GET("/items/assigned", handler.getAssignedItems, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", 1)
+			return next(c)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/assigned", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, `{"todoItems":[{"id":5,"title":"item","description":null,"Done":false,"statusId":null,`+
+		`"version":2,"listId":2}]}`+"\n", rec.Body.String())
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// multipartOverhead is the room left for multipart headers and boundaries on
+// top of the attachment size limit.
+const multipartOverhead = 64 << 10
+
+func (h *Handler) uploadAttachment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, service.MaxAttachmentSize+multipartOverhead)
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return newErrorResponse(400, "Request must be multipart/form-data")
+	}
+
+	var part io.ReadCloser
+	var name string
+	for {
+		p, err := reader.NextPart()
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return service.ErrAttachmentTooLarge
+			}
+			if errors.Is(err, io.EOF) {
+				return newErrorResponse(400, "File field is required")
+			}
+			return newErrorResponse(400, err.Error())
+		}
+		if p.FormName() == "file" {
+			part, name = p, p.FileName()
+			break
+		}
+		p.Close()
+	}
+	defer part.Close()
+
+	attachment, err := h.services.Attachment.Upload(req.Context(), userId, todoItemId, name, part)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return service.ErrAttachmentTooLarge
+		}
+		return err
+	}
+
+	return c.JSON(201, map[string]interface{}{
+		"attachment": attachment,
+	})
+}
+
+func (h *Handler) getAllAttachments(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	attachments, err := h.services.Attachment.GetAll(userId, todoItemId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"attachments": attachments,
+	})
+}
+
+func (h *Handler) downloadAttachment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	attachmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "AttachmentId is no integer value")
+	}
+
+	attachment, content, err := h.services.Attachment.Open(c.Request().Context(), userId, attachmentId)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Name,
+	}))
+	header.Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "sandbox")
+	header.Set("Cache-Control", "private, no-cache")
+	header.Set("ETag", strconv.Quote(attachment.Checksum))
+
+	if err = c.Stream(200, attachment.ContentType, content); err != nil {
+		logrus.Error(err)
+	}
+	return nil
+}
+
+func (h *Handler) deleteAttachment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	attachmentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "AttachmentId is no integer value")
+	}
+
+	err = h.services.Attachment.Delete(userId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": "ok",
+	})
+}
@@ -1,11 +1,9 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
-	"github.com/IvanMeln1k/go-todo-app/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
@@ -15,14 +13,11 @@ func (h *Handler) signUp(c echo.Context) error {
 		return newErrorResponse(http.StatusBadRequest, err.Error())
 	}
 	if err := c.Validate(user); err != nil {
-		return newErrorResponse(http.StatusBadRequest, "invalid body")
+		return h.validationError(c, err)
 	}
 	id, err := h.services.CreateUser(*user)
 	if err != nil {
-		if errors.Is(err, service.ErrUsernameAlreadyInUse) {
-			return newErrorResponse(409, "Username already in use")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+		return err
 	}
 	return c.JSON(200, map[string]interface{}{
 		"id": id,
@@ -40,17 +35,12 @@ func (h *Handler) signIn(c echo.Context) error {
 		return newErrorResponse(400, err.Error())
 	}
 	if err := c.Validate(user); err != nil {
-		return newErrorResponse(400, err.Error())
+		return h.validationError(c, err)
 	}
 
 	tokens, err := h.services.Authorization.SignIn(c.Request().Context(), user.Username, user.Password)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			return newErrorResponse(401, "Invalid username or password")
-		} else if errors.Is(err, service.ErrInternal) {
-			return newErrorResponse(500, "Internal server error")
-		}
-		return newErrorResponse(401, "Anauthorized")
+		return err
 	}
 
 	c.SetCookie(&http.Cookie{
@@ -70,14 +60,7 @@ func (h *Handler) refresh(c echo.Context) error {
 	}
 	tokens, err := h.services.Authorization.Refresh(c.Request().Context(), refreshToken.Value)
 	if err != nil {
-		if errors.Is(err, service.ErrSessionExpiredOrInvalid) {
-			return newErrorResponse(401, "Unauthorized")
-		} else if errors.Is(err, service.ErrInvalidSession) {
-			return newErrorResponse(401, "Invalid session")
-		} else if errors.Is(err, service.ErrInternal) {
-			return newErrorResponse(500, "Internal server error")
-		}
-		return newErrorResponse(401, "Unauthorized")
+		return err
 	}
 	c.SetCookie(&http.Cookie{
 		Name:     "refreshToken",
@@ -96,10 +79,7 @@ func (h *Handler) logout(c echo.Context) error {
 	}
 	err = h.services.Authorization.Logout(c.Request().Context(), refreshToken.Value)
 	if err != nil {
-		if errors.Is(err, service.ErrSessionExpiredOrInvalid) {
-			return newErrorResponse(401, "Unauthorized")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 	return c.JSON(200, map[string]interface{}{
 		"status": "ok",
@@ -113,10 +93,7 @@ func (h *Handler) logoutAll(c echo.Context) error {
 	}
 	err = h.services.LogoutAll(c.Request().Context(), refreshToken.Value)
 	if err != nil {
-		if errors.Is(err, service.ErrSessionExpiredOrInvalid) {
-			return newErrorResponse(401, "Unauthorized")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 	return c.JSON(200, map[string]interface{}{
 		"status": "ok",
@@ -10,7 +10,6 @@ import (
 	"github.com/IvanMeln1k/go-todo-app/internal/service"
 	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
 	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
-	"github.com/go-playground/validator"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -42,12 +41,16 @@ func TestHandler_signUp(t *testing.T) {
 			expectedRequestBody: "{\"id\":1}\n",
 		},
 		{
-			name:                "empty fields",
-			inputBody:           `{}`,
-			inputUser:           domain.User{},
-			mockBehavior:        func(s *mock_service.MockAuthorization, user domain.User) {},
-			expectedStatusCode:  400,
-			expectedRequestBody: "{\"message\":\"invalid body\"}\n",
+			name:               "empty fields",
+			inputBody:          `{}`,
+			inputUser:          domain.User{},
+			mockBehavior:       func(s *mock_service.MockAuthorization, user domain.User) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"name","rule":"required","message":"name is a required field"},` +
+				`{"field":"username","rule":"required","message":"username is a required field"},` +
+				`{"field":"password","rule":"required","message":"password is a required field"}],` +
+				`"instance":"/signUp","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
 		},
 		{
 			name:      "username already in use",
@@ -60,8 +63,9 @@ func TestHandler_signUp(t *testing.T) {
 			mockBehavior: func(s *mock_service.MockAuthorization, user domain.User) {
 				s.EXPECT().CreateUser(user).Return(0, service.ErrUsernameAlreadyInUse)
 			},
-			expectedStatusCode:  409,
-			expectedRequestBody: "{\"message\":\"Username already in use\"}\n",
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"code":"username_taken","detail":"Username already in use","instance":"/signUp",` +
+				`"status":409,"title":"Conflict","type":"about:blank"}` + "\n",
 		},
 	}
 
@@ -78,7 +82,8 @@ func TestHandler_signUp(t *testing.T) {
 
 			e := echo.New()
 			e.POST("/signUp", handler.signUp)
-			e.Validator = &validate.CustomValidator{Validator: validator.New()}
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
 
 			req := httptest.NewRequest(http.MethodPost, "/signUp",
 				strings.NewReader(testCase.inputBody))
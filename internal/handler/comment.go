@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) createComment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	var input domain.CommentInput
+	if err = c.Bind(&input); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&input); err != nil {
+		return h.validationError(c, err)
+	}
+
+	comment, err := h.services.Comment.Create(userId, todoItemId, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, map[string]interface{}{
+		"comment": comment,
+	})
+}
+
+func (h *Handler) getAllComments(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.services.Comment.GetAll(userId, todoItemId, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, page)
+}
+
+func (h *Handler) updateComment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "CommentId is no integer value")
+	}
+
+	var input domain.CommentInput
+	if err = c.Bind(&input); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&input); err != nil {
+		return h.validationError(c, err)
+	}
+
+	comment, err := h.services.Comment.Update(userId, commentId, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"comment": comment,
+	})
+}
+
+func (h *Handler) deleteComment(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "CommentId is no integer value")
+	}
+
+	err = h.services.Comment.Delete(userId, commentId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": "ok",
+	})
+}
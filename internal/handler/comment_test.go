@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_updateComment(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockComment, input domain.CommentInput)
+
+	createdAt := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                string
+		commentId           string
+		inputBody           string
+		input               domain.CommentInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			commentId: "3",
+			inputBody: `{"body":"**done**"}`,
+			input:     domain.CommentInput{Body: "**done**"},
+			mockBehavior: func(s *mock_service.MockComment, input domain.CommentInput) {
+				s.EXPECT().Update(1, 3, input).Return(domain.Comment{
+					Id:        3,
+					ItemId:    2,
+					Author:    domain.CommentAuthor{Id: 1, Name: "Ivan", Username: "ivan"},
+					Body:      "**done**",
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"comment":{"id":3,"itemId":2,"author":{"id":1,"name":"Ivan","username":"ivan"},` +
+				`"body":"**done**","createdAt":"2024-03-01T09:30:00Z","updatedAt":"2024-03-01T09:30:00Z"}}` + "\n",
+		},
+		{
+			name:               "empty body",
+			commentId:          "3",
+			inputBody:          `{"body":""}`,
+			mockBehavior:       func(s *mock_service.MockComment, input domain.CommentInput) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"body","rule":"required","message":"body is a required field"}],` +
+				`"instance":"/comments/3","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name:      "not the author",
+			commentId: "3",
+			inputBody: `{"body":"edited"}`,
+			input:     domain.CommentInput{Body: "edited"},
+			mockBehavior: func(s *mock_service.MockComment, input domain.CommentInput) {
+				s.EXPECT().Update(1, 3, input).Return(domain.Comment{}, service.ErrNotCommentAuthor)
+			},
+			expectedStatusCode: 403,
+			expectedRequestBody: `{"code":"not_comment_author","detail":"Only the author can change the comment",` +
+				`"instance":"/comments/3","status":403,"title":"Forbidden","type":"about:blank"}` + "\n",
+		},
+		{
+			name:      "not found",
+			commentId: "3",
+			inputBody: `{"body":"edited"}`,
+			input:     domain.CommentInput{Body: "edited"},
+			mockBehavior: func(s *mock_service.MockComment, input domain.CommentInput) {
+				s.EXPECT().Update(1, 3, input).Return(domain.Comment{}, domain.ErrCommentNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"comment_not_found","detail":"Comment not found","instance":"/comments/3",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			comment := mock_service.NewMockComment(c)
+			testCase.mockBehavior(comment, testCase.input)
+
+			services := &service.Service{Comment: comment}
+			handler := NewHandler(services)
+
+			e := echo.New()
+			e.PUT("/comments/:id", handler.updateComment, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPut, "/comments/"+testCase.commentId,
+				strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
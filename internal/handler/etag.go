@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ETags of lists and items are their versions, so they change on every write.
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set("ETag", etag(version))
+}
+
+// getIfMatch reads the If-Match header as the version the client expects to
+// change. It is nil when the header is absent or "*".
+func getIfMatch(c echo.Context) (*int, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	if strings.Contains(header, ",") {
+		return nil, newErrorResponse(400, "If-Match must contain a single ETag")
+	}
+	// Weak ETags never match in If-Match, the comparison is strong.
+	if strings.HasPrefix(header, "W/") {
+		return nil, newErrorResponse(412, "Precondition failed")
+	}
+
+	value, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, newErrorResponse(400, "Invalid If-Match header")
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, newErrorResponse(400, "Invalid If-Match header")
+	}
+	return &version, nil
+}
+
+// notModified reports whether the If-None-Match header matches the version.
+func notModified(c echo.Context, version int) bool {
+	header := c.Request().Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	tag := etag(version)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
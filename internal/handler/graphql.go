@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/graph"
+	"github.com/labstack/echo/v4"
+)
+
+// graphql answers with 200 whatever the outcome, errors of the query are
+// reported in the result the way GraphQL clients expect them.
+func (h *Handler) graphql(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	var request graph.Request
+	if err = c.Bind(&request); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+
+	caller := graph.Caller{
+		UserId: userId,
+		Validate: func(input interface{}) error {
+			if err := h.validator.Validate(input); err != nil {
+				return h.validationError(c, err)
+			}
+			return nil
+		},
+	}
+
+	return c.JSON(200, h.graph.Execute(c.Request().Context(), caller, request))
+}
@@ -1,26 +1,39 @@
 package handler
 
 import (
+	"github.com/IvanMeln1k/go-todo-app/internal/graph"
 	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/IvanMeln1k/go-todo-app/pkg/openapi"
 	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
-	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
 )
 
 type Handler struct {
-	services *service.Service
+	services  *service.Service
+	validator *validate.CustomValidator
+	// apiDocument is the OpenAPI document served at /openapi.json.
+	apiDocument *openapi.Document
+	graph       *graph.Executor
 }
 
 func NewHandler(services *service.Service) *Handler {
 	return &Handler{
-		services: services,
+		services:    services,
+		validator:   validate.NewCustomValidator(),
+		apiDocument: newAPIDocument(),
+		graph:       graph.NewExecutor(services, graph.DefaultLimits),
 	}
 }
 
 func (h *Handler) InitRoutes() *echo.Echo {
 	router := echo.New()
 
-	router.Validator = &validate.CustomValidator{Validator: validator.New()}
+	router.Validator = h.validator
+	router.HTTPErrorHandler = errorHandler
+
+	router.GET("/openapi.json", h.getOpenAPI)
+	router.GET("/docs", h.getDocs)
+	router.POST("/graphql", h.graphql, h.userIdentity)
 
 	auth := router.Group("/auth")
 	{
@@ -31,7 +44,7 @@ func (h *Handler) InitRoutes() *echo.Echo {
 		auth.DELETE("/logout-all", h.logoutAll)
 	}
 
-	api := router.Group("/api", h.userIdentity)
+	api := router.Group("/api", h.userIdentity, h.idempotency)
 	{
 		lists := api.Group("/lists")
 		{
@@ -39,20 +52,100 @@ func (h *Handler) InitRoutes() *echo.Echo {
 			lists.GET("/", h.getAllLists)
 			lists.GET("/:id", h.getListById)
 			lists.PUT("/:id", h.updateList)
+			lists.PATCH("/:id", h.patchList)
 			lists.DELETE("/:id", h.deleteList)
+			lists.POST("/:id/archive", h.archiveList)
+			lists.DELETE("/:id/archive", h.unarchiveList)
+			lists.POST("/:id/pin", h.pinList)
+			lists.DELETE("/:id/pin", h.unpinList)
+			lists.POST("/:id/duplicate", h.duplicateList)
+			lists.POST("/:id/template", h.createTemplate)
+			lists.GET("/:id/statuses", h.getAllStatuses)
+			lists.POST("/:id/statuses", h.createStatus)
+			lists.GET("/:id/board", h.getBoard)
+			lists.GET("/:id/members", h.getListMembers)
+			lists.GET("/:id/activity", h.getListActivity)
+			lists.POST("/:id/webhooks", h.createWebhook)
+			lists.GET("/:id/webhooks", h.getAllWebhooks)
 
 			items := lists.Group("/:id/items")
 			{
 				items.POST("/", h.createItem)
 				items.GET("/", h.getAllItems)
+				items.POST("/bulk", h.bulkItems)
 			}
 		}
 
 		items := api.Group("/items")
 		{
+			items.GET("/assigned", h.getAssignedItems)
 			items.GET("/:id", h.getItemById)
 			items.PUT("/:id", h.updateItem)
+			items.PATCH("/:id", h.patchItem)
 			items.DELETE("/:id", h.deleteItem)
+			items.POST("/:id/move", h.moveItem)
+			items.POST("/:id/copy", h.copyItem)
+			items.PUT("/:id/status", h.setItemStatus)
+			items.GET("/:id/assignees", h.getItemAssignees)
+			items.PUT("/:id/assignees", h.setItemAssignees)
+			items.GET("/:id/comments", h.getAllComments)
+			items.POST("/:id/comments", h.createComment)
+			items.GET("/:id/attachments", h.getAllAttachments)
+			items.POST("/:id/attachments", h.uploadAttachment)
+			items.GET("/:id/history", h.getItemHistory)
+		}
+
+		comments := api.Group("/comments")
+		{
+			comments.PUT("/:id", h.updateComment)
+			comments.DELETE("/:id", h.deleteComment)
+		}
+
+		attachments := api.Group("/attachments")
+		{
+			attachments.GET("/:id/content", h.downloadAttachment)
+			attachments.DELETE("/:id", h.deleteAttachment)
+		}
+
+		statuses := api.Group("/statuses")
+		{
+			statuses.PUT("/:id", h.updateStatus)
+			statuses.DELETE("/:id", h.deleteStatus)
+		}
+
+		templates := api.Group("/templates")
+		{
+			templates.GET("/", h.getAllTemplates)
+			templates.GET("/:id", h.getTemplateById)
+			templates.DELETE("/:id", h.deleteTemplate)
+			templates.POST("/:id/instantiate", h.instantiateTemplate)
+		}
+
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.GET("/:id", h.getWebhookById)
+			webhooks.PUT("/:id", h.updateWebhook)
+			webhooks.DELETE("/:id", h.deleteWebhook)
+			webhooks.GET("/:id/deliveries", h.getWebhookDeliveries)
+		}
+
+		api.POST("/deliveries/:id/redeliver", h.redeliver)
+
+		api.GET("/stream", h.stream)
+
+		api.GET("/sync", h.getSyncChanges)
+		api.POST("/sync", h.sync)
+
+		api.POST("/undo", h.undoLast)
+		api.POST("/undo/:id", h.undoActivity)
+
+		trash := api.Group("/trash")
+		{
+			trash.GET("/", h.getTrash)
+			trash.POST("/lists/:id/restore", h.restoreListFromTrash)
+			trash.DELETE("/lists/:id", h.deleteListFromTrash)
+			trash.POST("/items/:id/restore", h.restoreItemFromTrash)
+			trash.DELETE("/items/:id", h.deleteItemFromTrash)
 		}
 	}
 
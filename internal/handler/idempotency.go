@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBodySize bounds the bodies read to fingerprint a request, an
+// attachment upload is the largest POST body accepted.
+const maxIdempotentBodySize = service.MaxAttachmentSize + multipartOverhead
+
+// replayedHeaders are the response headers kept along with the body.
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "ETag"}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotency makes POST requests sent with an Idempotency-Key header safe to
+// retry: the first response is stored per user and replayed for the same
+// key. Errors are not stored, so a failed request can be retried.
+func (h *Handler) idempotency(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get("Idempotency-Key")
+		if c.Request().Method != http.MethodPost || key == "" {
+			return next(c)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return newErrorResponse(400, "Idempotency-Key is too long")
+		}
+
+		userId, err := getUserId(c)
+		if err != nil {
+			return err
+		}
+
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxIdempotentBodySize)
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return newErrorResponse(413, "Request body is too large")
+			}
+			return newErrorResponse(400, err.Error())
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := requestFingerprint(c.Request(), body)
+		stored, err := h.services.Idempotency.Begin(c.Request().Context(), userId, key, fingerprint)
+		if err != nil {
+			if errors.Is(err, service.ErrIdempotencyKeyInProgress) {
+				c.Response().Header().Set("Retry-After", "1")
+			}
+			return err
+		}
+		if stored != nil {
+			for name, value := range stored.Header {
+				c.Response().Header().Set(name, value)
+			}
+			c.Response().Header().Set("Idempotent-Replayed", "true")
+			c.Response().WriteHeader(stored.StatusCode)
+			_, err = c.Response().Write(stored.Body)
+			return err
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		err = next(c)
+		c.Response().Writer = recorder.ResponseWriter
+
+		// The outcome is saved even if the client has gone away meanwhile,
+		// that is exactly when it is going to retry.
+		ctx := context.Background()
+		if err != nil || !c.Response().Committed || c.Response().Status >= 500 {
+			if abortErr := h.services.Idempotency.Abort(ctx, userId, key); abortErr != nil {
+				logrus.Error(abortErr)
+			}
+			return err
+		}
+
+		response := domain.IdempotentResponse{
+			Fingerprint: fingerprint,
+			StatusCode:  c.Response().Status,
+			Header:      map[string]string{},
+			Body:        recorder.body.Bytes(),
+		}
+		for _, name := range replayedHeaders {
+			if value := c.Response().Header().Get(name); value != "" {
+				response.Header[name] = value
+			}
+		}
+		if err = h.services.Idempotency.Complete(ctx, userId, key, response); err != nil {
+			logrus.Error(err)
+		}
+		return nil
+	}
+}
+
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
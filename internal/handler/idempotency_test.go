@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_idempotency(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockIdempotency, fingerprint string)
+
+	testTable := []struct {
+		name                string
+		key                 string
+		mockBehavior        mockBehavior
+		expectedHandled     bool
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "first request",
+			key:  "key-1",
+			mockBehavior: func(s *mock_service.MockIdempotency, fingerprint string) {
+				s.EXPECT().Begin(gomock.Any(), 1, "key-1", fingerprint).Return(nil, nil)
+				s.EXPECT().Complete(gomock.Any(), 1, "key-1", domain.IdempotentResponse{
+					Fingerprint: fingerprint,
+					StatusCode:  201,
+					Header:      map[string]string{echo.HeaderContentType: echo.MIMEApplicationJSONCharsetUTF8},
+					Body:        []byte("7\n"),
+				}).Return(nil)
+			},
+			expectedHandled:     true,
+			expectedStatusCode:  201,
+			expectedRequestBody: "7\n",
+		},
+		{
+			name: "retry",
+			key:  "key-1",
+			mockBehavior: func(s *mock_service.MockIdempotency, fingerprint string) {
+				s.EXPECT().Begin(gomock.Any(), 1, "key-1", fingerprint).Return(&domain.IdempotentResponse{
+					Fingerprint: fingerprint,
+					StatusCode:  201,
+					Header:      map[string]string{echo.HeaderContentType: echo.MIMEApplicationJSONCharsetUTF8},
+					Body:        []byte("7\n"),
+				}, nil)
+			},
+			expectedStatusCode:  201,
+			expectedRequestBody: "7\n",
+		},
+		{
+			name: "different request",
+			key:  "key-1",
+			mockBehavior: func(s *mock_service.MockIdempotency, fingerprint string) {
+				s.EXPECT().Begin(gomock.Any(), 1, "key-1", fingerprint).Return(nil, service.ErrIdempotencyKeyReused)
+			},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"idempotency_key_reused","detail":"Idempotency-Key was used with a different request",` +
+				`"instance":"/lists/","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "in progress",
+			key:  "key-1",
+			mockBehavior: func(s *mock_service.MockIdempotency, fingerprint string) {
+				s.EXPECT().Begin(gomock.Any(), 1, "key-1", fingerprint).Return(nil, service.ErrIdempotencyKeyInProgress)
+			},
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"code":"idempotency_key_in_progress","detail":"Request with this Idempotency-Key is in progress",` +
+				`"instance":"/lists/","status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+		{
+			name:                "no key",
+			mockBehavior:        func(s *mock_service.MockIdempotency, fingerprint string) {},
+			expectedHandled:     true,
+			expectedStatusCode:  201,
+			expectedRequestBody: "7\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			idempotency := mock_service.NewMockIdempotency(c)
+
+			handler := NewHandler(&service.Service{Idempotency: idempotency})
+
+			handled := false
+			e := echo.New()
+			e.HTTPErrorHandler = errorHandler
+			e.POST("/lists/", func(c echo.Context) error {
+				handled = true
+				return c.JSON(201, 7)
+			}, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			}, handler.idempotency)
+
+			inputBody := `{"title":"list"}`
+			req := httptest.NewRequest(http.MethodPost, "/lists/", strings.NewReader(inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Idempotency-Key", testCase.key)
+			rec := httptest.NewRecorder()
+
+			testCase.mockBehavior(idempotency, requestFingerprint(req, []byte(inputBody)))
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedHandled, handled)
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_idempotency_bodyTooLarge(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	// Neither the key nor the upload is handled.
+	idempotency := mock_service.NewMockIdempotency(c)
+	attachment := mock_service.NewMockAttachment(c)
+
+	handler := NewHandler(&service.Service{Idempotency: idempotency, Attachment: attachment})
+
+	e := echo.New()
+	e.HTTPErrorHandler = errorHandler
+	e.POST("/items/:id/attachments", handler.uploadAttachment, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", 1)
+			return next(c)
+		}
+	}, handler.idempotency)
+
+	body := strings.NewReader(strings.Repeat("a", maxIdempotentBodySize+1))
+	req := httptest.NewRequest(http.MethodPost, "/items/3/attachments", body)
+	req.Header.Set(echo.HeaderContentType, "multipart/form-data; boundary=x")
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, 413, rec.Code)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
@@ -23,15 +24,12 @@ func (h *Handler) createItem(c echo.Context) error {
 		return newErrorResponse(400, err.Error())
 	}
 	if err = c.Validate(&todoItem); err != nil {
-		return newErrorResponse(400, err.Error())
+		return h.validationError(c, err)
 	}
 
 	todoItemId, err := h.services.TodoItem.Create(userId, todoListId, todoItem)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 
 	return c.JSON(201, todoItemId)
@@ -50,10 +48,7 @@ func (h *Handler) getAllItems(c echo.Context) error {
 
 	todoItems, err := h.services.TodoItem.GetAll(userId, todoListId)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 
 	return c.JSON(200, map[string]interface{}{
@@ -74,10 +69,12 @@ func (h *Handler) getItemById(c echo.Context) error {
 
 	todoItem, err := h.services.TodoItem.GetById(userId, todoItemId)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Item not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
+	}
+
+	setETag(c, todoItem.Version)
+	if notModified(c, todoItem.Version) {
+		return c.NoContent(304)
 	}
 
 	return c.JSON(200, map[string]interface{}{
@@ -96,12 +93,14 @@ func (h *Handler) deleteItem(c echo.Context) error {
 		return newErrorResponse(400, "TodoItemId is no integer value")
 	}
 
-	err = h.services.TodoItem.Delete(userId, todoItemId)
+	version, err := getIfMatch(c)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Item not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
+	}
+
+	err = h.services.TodoItem.Delete(userId, todoItemId, version)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(200, map[string]interface{}{
@@ -120,23 +119,119 @@ func (h *Handler) updateItem(c echo.Context) error {
 		return newErrorResponse(400, "TodoItemId is no integer value")
 	}
 
-	var updateTodoItem domain.UpdateTodoItem
-	if err = c.Bind(&updateTodoItem); err != nil {
+	var replaceTodoItem domain.ReplaceTodoItem
+	if err = c.Bind(&replaceTodoItem); err != nil {
 		return newErrorResponse(400, err.Error())
 	}
-	if err = updateTodoItem.Validate(); err != nil {
-		return newErrorResponse(400, err.Error())
+	if err = c.Validate(&replaceTodoItem); err != nil {
+		return h.validationError(c, err)
+	}
+	if replaceTodoItem.Version, err = getIfMatch(c); err != nil {
+		return err
 	}
 
-	todoItem, err := h.services.TodoItem.Update(userId, todoItemId, updateTodoItem)
+	todoItem, err := h.services.TodoItem.Replace(userId, todoItemId, replaceTodoItem)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "TodoItem not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 
+	setETag(c, todoItem.Version)
+
 	return c.JSON(201, map[string]interface{}{
 		"todoItem": todoItem,
 	})
 }
+
+func (h *Handler) moveItem(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	var destination domain.TodoItemDestination
+	if err = c.Bind(&destination); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&destination); err != nil {
+		return h.validationError(c, err)
+	}
+
+	todoItem, err := h.services.TodoItem.Move(userId, todoItemId, destination.ListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"todoItem": todoItem,
+	})
+}
+
+func (h *Handler) copyItem(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	var destination domain.TodoItemDestination
+	if err = c.Bind(&destination); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&destination); err != nil {
+		return h.validationError(c, err)
+	}
+
+	todoItemCopyId, err := h.services.TodoItem.Copy(userId, todoItemId, destination.ListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, todoItemCopyId)
+}
+
+func (h *Handler) bulkItems(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	var bulk domain.BulkItemOperations
+	if err = c.Bind(&bulk); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&bulk); err != nil {
+		return h.validationError(c, err)
+	}
+	for i, operation := range bulk.Operations {
+		if err = operation.Validate(); err != nil {
+			return domain.ErrValidation.WithFields(domain.FieldError{
+				Field:   fmt.Sprintf("operations[%d]", i),
+				Rule:    "operation",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	results, err := h.services.TodoItem.Bulk(userId, todoListId, bulk.Operations)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"results": results,
+	})
+}
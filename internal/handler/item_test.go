@@ -0,0 +1,484 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/IvanMeln1k/go-todo-app/pkg/patch"
+	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_updateItem(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem, input domain.ReplaceTodoItem)
+
+	version := 3
+
+	testTable := []struct {
+		name                string
+		ifMatch             string
+		inputBody           string
+		input               domain.ReplaceTodoItem
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedETag        string
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok",
+			ifMatch: `"3"`,
+			input:   domain.ReplaceTodoItem{Title: "new", Version: &version},
+			mockBehavior: func(s *mock_service.MockTodoItem, input domain.ReplaceTodoItem) {
+				s.EXPECT().Replace(1, 2, input).Return(domain.TodoItem{Id: 2, Title: "new", Version: 4}, nil)
+			},
+			expectedStatusCode: 201,
+			expectedETag:       `"4"`,
+			expectedRequestBody: `{"todoItem":{"id":2,"title":"new","description":null,"Done":false,"statusId":null,` +
+				`"version":4}}` + "\n",
+		},
+		{
+			name:    "version mismatch",
+			ifMatch: `"3"`,
+			input:   domain.ReplaceTodoItem{Title: "new", Version: &version},
+			mockBehavior: func(s *mock_service.MockTodoItem, input domain.ReplaceTodoItem) {
+				s.EXPECT().Replace(1, 2, input).Return(domain.TodoItem{}, service.ErrVersionMismatch)
+			},
+			expectedStatusCode: 412,
+			expectedRequestBody: `{"code":"version_mismatch","detail":"Record has changed since it was read","instance":"/items/2",` +
+				`"status":412,"title":"Precondition Failed","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "missing title",
+			inputBody:          `{"description":"text"}`,
+			mockBehavior:       func(s *mock_service.MockTodoItem, input domain.ReplaceTodoItem) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"title","rule":"required","message":"title is a required field"}],` +
+				`"instance":"/items/2","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "invalid If-Match",
+			ifMatch:            "3",
+			mockBehavior:       func(s *mock_service.MockTodoItem, input domain.ReplaceTodoItem) {},
+			expectedStatusCode: 400,
+			expectedRequestBody: `{"code":"bad_request","detail":"Invalid If-Match header","instance":"/items/2",` +
+				`"status":400,"title":"Bad Request","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			testCase.mockBehavior(todoItem, testCase.input)
+
+			services := &service.Service{TodoItem: todoItem}
+			handler := NewHandler(services)
+
+			e := echo.New()
+			e.PUT("/items/:id", handler.updateItem, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			inputBody := testCase.inputBody
+			if inputBody == "" {
+				inputBody = `{"title":"new","description":null,"done":false}`
+			}
+			req := httptest.NewRequest(http.MethodPut, "/items/2", strings.NewReader(inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("If-Match", testCase.ifMatch)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedETag, rec.Header().Get("ETag"))
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_patchItem(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	description := "text"
+	stored := domain.TodoItem{Id: 2, Title: "item", Description: &description, Version: 3}
+
+	testTable := []struct {
+		name                string
+		contentType         string
+		ifMatch             string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:        "merge patch",
+			contentType: patch.MergePatchType,
+			inputBody:   `{"description":null,"done":true}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				version := 3
+				s.EXPECT().GetById(1, 2).Return(stored, nil)
+				s.EXPECT().Replace(1, 2, domain.ReplaceTodoItem{Title: "item", Done: true, Version: &version}).
+					Return(domain.TodoItem{Id: 2, Title: "item", Done: true, Version: 4}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"todoItem":{"id":2,"title":"item","description":null,"Done":true,"statusId":null,` +
+				`"version":4}}` + "\n",
+		},
+		{
+			name:        "json patch retried after concurrent change",
+			contentType: patch.JSONPatchType,
+			inputBody:   `[{"op":"replace","path":"/title","value":"new"}]`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				changed := stored
+				changed.Version = 4
+				first, second := 3, 4
+				gomock.InOrder(
+					s.EXPECT().GetById(1, 2).Return(stored, nil),
+					s.EXPECT().Replace(1, 2, domain.ReplaceTodoItem{Title: "new", Description: &description, Version: &first}).
+						Return(domain.TodoItem{}, service.ErrVersionMismatch),
+					s.EXPECT().GetById(1, 2).Return(changed, nil),
+					s.EXPECT().Replace(1, 2, domain.ReplaceTodoItem{Title: "new", Description: &description, Version: &second}).
+						Return(domain.TodoItem{Id: 2, Title: "new", Description: &description, Version: 5}, nil),
+				)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"todoItem":{"id":2,"title":"new","description":"text","Done":false,"statusId":null,` +
+				`"version":5}}` + "\n",
+		},
+		{
+			name:        "stale If-Match",
+			contentType: patch.MergePatchType,
+			ifMatch:     `"2"`,
+			inputBody:   `{"done":true}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetById(1, 2).Return(stored, nil)
+			},
+			expectedStatusCode: 412,
+			expectedRequestBody: `{"code":"version_mismatch","detail":"Record has changed since it was read","instance":"/items/2",` +
+				`"status":412,"title":"Precondition Failed","type":"about:blank"}` + "\n",
+		},
+		{
+			name:        "failed test operation",
+			contentType: patch.JSONPatchType,
+			inputBody:   `[{"op":"test","path":"/done","value":true}]`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetById(1, 2).Return(stored, nil)
+			},
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"code":"patch_test_failed","detail":"Patch test failed","instance":"/items/2",` +
+				`"status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+		{
+			name:        "title removed",
+			contentType: patch.MergePatchType,
+			inputBody:   `{"title":null}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetById(1, 2).Return(stored, nil)
+			},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"title","rule":"required","message":"title is a required field"}],` +
+				`"instance":"/items/2","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "unsupported media type",
+			contentType:        echo.MIMEApplicationJSON,
+			inputBody:          `{"done":true}`,
+			mockBehavior:       func(s *mock_service.MockTodoItem) {},
+			expectedStatusCode: 415,
+			expectedRequestBody: `{"code":"unsupported_media_type","detail":"Unsupported patch media type","instance":"/items/2",` +
+				`"status":415,"title":"Unsupported Media Type","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			testCase.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			e := echo.New()
+			e.PATCH("/items/:id", handler.patchItem, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/2", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, testCase.contentType)
+			req.Header.Set("If-Match", testCase.ifMatch)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_getItemById_notModified(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	todoItem := mock_service.NewMockTodoItem(c)
+	todoItem.EXPECT().GetById(1, 2).Return(domain.TodoItem{Id: 2, Title: "item", Version: 4}, nil).Times(2)
+
+	handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+	e := echo.New()
+	e.GET("/items/:id", handler.getItemById, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", 1)
+			return next(c)
+		}
+	})
+
+	for ifNoneMatch, expectedStatusCode := range map[string]int{`"4"`: 304, `"3", W/"5"`: 200} {
+		req := httptest.NewRequest(http.MethodGet, "/items/2", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, expectedStatusCode, rec.Code)
+		assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	}
+}
+
+func TestHandler_moveItem(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"listId":5}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Move(1, 2, 5).Return(domain.TodoItem{Id: 2, Title: "item", Version: 1}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"todoItem":{"id":2,"title":"item","description":null,"Done":false,"statusId":null,` +
+				`"version":1}}` + "\n",
+		},
+		{
+			name:      "archived destination",
+			inputBody: `{"listId":5}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Move(1, 2, 5).Return(domain.TodoItem{}, domain.ErrListArchived)
+			},
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"code":"list_archived","detail":"List is archived","instance":"/items/2/move",` +
+				`"status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "missing list",
+			inputBody:          `{}`,
+			mockBehavior:       func(s *mock_service.MockTodoItem) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"listId","rule":"required","message":"listId is a required field"}],` +
+				`"instance":"/items/2/move","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			testCase.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			e := echo.New()
+			e.POST("/items/:id/move", handler.moveItem, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPost, "/items/2/move", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_copyItem(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	testTable := []struct {
+		name                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Copy(1, 2, 5).Return(7, nil)
+			},
+			expectedStatusCode:  201,
+			expectedRequestBody: "7\n",
+		},
+		{
+			name: "item not found",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Copy(1, 2, 5).Return(0, domain.ErrItemNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"item_not_found","detail":"Item not found","instance":"/items/2/copy",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			testCase.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			e := echo.New()
+			e.POST("/items/:id/copy", handler.copyItem, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPost, "/items/2/copy", strings.NewReader(`{"listId":5}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_bulkItems(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	title := "new"
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"operations":[{"op":"create","title":"new"},{"op":"delete","id":4}]}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Bulk(1, 2, []domain.BulkItemOperation{
+					{Op: domain.BulkOperationCreate, Title: &title},
+					{Op: domain.BulkOperationDelete, Id: 4},
+				}).Return([]domain.BulkItemResult{
+					{Op: domain.BulkOperationCreate, Id: 7},
+					{Op: domain.BulkOperationDelete, Id: 4},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"results":[{"op":"create","id":7},{"op":"delete","id":4}]}` + "\n",
+		},
+		{
+			name:      "failed operation",
+			inputBody: `{"operations":[{"op":"create","title":"new"},{"op":"delete","id":4}]}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Bulk(1, 2, gomock.Any()).Return(nil, domain.ErrItemNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"item_not_found","detail":"Item not found","instance":"/lists/2/items/bulk",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "invalid operation",
+			inputBody:          `{"operations":[{"op":"create","title":"new"},{"op":"delete"}]}`,
+			mockBehavior:       func(s *mock_service.MockTodoItem) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"operations[1]","rule":"operation","message":"delete operation has no id"}],` +
+				`"instance":"/lists/2/items/bulk","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			testCase.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			e := echo.New()
+			e.POST("/lists/:id/items/bulk", handler.bulkItems, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPost, "/lists/2/items/bulk", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
@@ -19,12 +19,12 @@ func (h *Handler) createList(c echo.Context) error {
 		return newErrorResponse(400, err.Error())
 	}
 	if err = c.Validate(todoList); err != nil {
-		return newErrorResponse(400, err.Error())
+		return h.validationError(c, err)
 	}
 
 	todoListId, err := h.services.TodoList.Create(userId, *todoList)
 	if err != nil {
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 
 	return c.JSON(201, todoListId)
@@ -36,9 +36,17 @@ func (h *Handler) getAllLists(c echo.Context) error {
 		return err
 	}
 
-	todoLists, err := h.services.TodoList.GetAll(userId)
+	archived := false
+	if c.QueryParam("archived") != "" {
+		archived, err = strconv.ParseBool(c.QueryParam("archived"))
+		if err != nil {
+			return newErrorResponse(400, "Archived is no boolean value")
+		}
+	}
+
+	todoLists, err := h.services.TodoList.GetAll(userId, archived)
 	if err != nil {
-		return newErrorResponse(404, err.Error())
+		return err
 	}
 
 	return c.JSON(200, map[string]interface{}{
@@ -59,10 +67,12 @@ func (h *Handler) getListById(c echo.Context) error {
 
 	todoList, err := h.services.TodoList.GetById(userId, todoListId)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
+	}
+
+	setETag(c, todoList.Version)
+	if notModified(c, todoList.Version) {
+		return c.NoContent(304)
 	}
 
 	return c.JSON(200, map[string]interface{}{
@@ -81,23 +91,24 @@ func (h *Handler) updateList(c echo.Context) error {
 		return newErrorResponse(400, "Bad request")
 	}
 
-	var updateTodoList domain.UpdateTodoList
-	if err = c.Bind(&updateTodoList); err != nil {
+	var replaceTodoList domain.ReplaceTodoList
+	if err = c.Bind(&replaceTodoList); err != nil {
 		return newErrorResponse(400, err.Error())
 	}
-	err = updateTodoList.Validate()
-	if err != nil {
-		return newErrorResponse(400, "Update struct has no values")
+	if err = c.Validate(&replaceTodoList); err != nil {
+		return h.validationError(c, err)
+	}
+	if replaceTodoList.Version, err = getIfMatch(c); err != nil {
+		return err
 	}
 
-	todoList, err := h.services.TodoList.Update(userId, todoListId, updateTodoList)
+	todoList, err := h.services.TodoList.Replace(userId, todoListId, replaceTodoList)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
 	}
 
+	setETag(c, todoList.Version)
+
 	return c.JSON(201, map[string]interface{}{
 		"todoList": todoList,
 	})
@@ -114,15 +125,81 @@ func (h *Handler) deleteList(c echo.Context) error {
 		return newErrorResponse(400, "Bad request")
 	}
 
-	err = h.services.TodoList.Delete(userId, todoListId)
+	version, err := getIfMatch(c)
 	if err != nil {
-		if err.Error() == "not found" {
-			return newErrorResponse(404, "Not found")
-		}
-		return newErrorResponse(500, "Internal server error")
+		return err
+	}
+
+	err = h.services.TodoList.Delete(userId, todoListId, version)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(201, map[string]interface{}{
 		"status": "ok",
 	})
 }
+
+func (h *Handler) setListFlag(c echo.Context, set func(userId int, todoListId int) (domain.TodoList, error)) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	todoList, err := set(userId, todoListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"todoList": todoList,
+	})
+}
+
+func (h *Handler) archiveList(c echo.Context) error {
+	return h.setListFlag(c, h.services.TodoList.Archive)
+}
+
+func (h *Handler) unarchiveList(c echo.Context) error {
+	return h.setListFlag(c, h.services.TodoList.Unarchive)
+}
+
+func (h *Handler) pinList(c echo.Context) error {
+	return h.setListFlag(c, h.services.TodoList.Pin)
+}
+
+func (h *Handler) unpinList(c echo.Context) error {
+	return h.setListFlag(c, h.services.TodoList.Unpin)
+}
+
+func (h *Handler) duplicateList(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "Bad request")
+	}
+
+	var duplicate domain.DuplicateTodoList
+	if err = c.Bind(&duplicate); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&duplicate); err != nil {
+		return h.validationError(c, err)
+	}
+
+	todoListCopyId, err := h.services.TodoList.Duplicate(userId, todoListId, duplicate)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, todoListCopyId)
+}
@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"errors"
 	"strings"
 
-	"github.com/IvanMeln1k/go-todo-app/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
@@ -26,12 +24,7 @@ func (h *Handler) userIdentity(next echo.HandlerFunc) echo.HandlerFunc {
 		userId, err := h.services.Authorization.ParseToken(params[1])
 
 		if err != nil {
-			if errors.Is(err, service.ErrTokenExpired) {
-				return newErrorResponse(401, "Token is expired")
-			} else if errors.Is(err, service.ErrInvalidTokenSignature) {
-				return newErrorResponse(401, "Invalid token signature")
-			}
-			return newErrorResponse(500, "Internal server error")
+			return err
 		}
 
 		c.Set("userId", userId)
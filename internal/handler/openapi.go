@@ -0,0 +1,366 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/graph"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/IvanMeln1k/go-todo-app/pkg/openapi"
+	"github.com/IvanMeln1k/go-todo-app/pkg/patch"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed docs/index.html
+var docsPage []byte
+
+// apiOperation documents a route. Request and response are values of the
+// types the handler binds and renders, the schemas are generated from them.
+type apiOperation struct {
+	method   string
+	path     string
+	tag      string
+	summary  string
+	params   []openapi.Parameter
+	request  interface{}
+	status   int
+	response interface{}
+}
+
+// Request and response bodies that are not JSON.
+type (
+	// patchBody is a JSON Merge Patch of the value or a JSON Patch.
+	patchBody struct{ value interface{} }
+	// multipartFile is a multipart/form-data body with a file field.
+	multipartFile struct{}
+	// binaryContent is the raw content of a file.
+	binaryContent struct{}
+	// eventStream is a stream of server-sent events with the value as data.
+	eventStream struct{ value interface{} }
+)
+
+// jsonPatchOperation documents an operation of a JSON Patch.
+type jsonPatchOperation struct {
+	Op    string      `json:"op" validate:"required,oneof=add remove replace move copy test"`
+	Path  string      `json:"path" validate:"required"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// problem documents the body of error responses.
+type problem struct {
+	Type     string              `json:"type" validate:"required"`
+	Title    string              `json:"title" validate:"required"`
+	Status   int                 `json:"status" validate:"required"`
+	Detail   string              `json:"detail" validate:"required"`
+	Code     string              `json:"code" validate:"required"`
+	Instance string              `json:"instance" validate:"required"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// graphqlResult documents the result of a GraphQL request.
+type graphqlResult struct {
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Errors []graphqlError         `json:"errors,omitempty"`
+}
+
+type graphqlError struct {
+	Message    string                 `json:"message" validate:"required"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+var (
+	ifMatchParam = openapi.Parameter{Name: "If-Match", In: "header",
+		Description: "Makes the request conditional on the ETag of the record",
+		Schema:      &openapi.Schema{Type: "string"}}
+	ifNoneMatchParam = openapi.Parameter{Name: "If-None-Match", In: "header",
+		Description: "Answers 304 when the record still has one of the ETags",
+		Schema:      &openapi.Schema{Type: "string"}}
+	archivedParam = openapi.Parameter{Name: "archived", In: "query",
+		Description: "Lists archived lists instead of active ones",
+		Schema:      &openapi.Schema{Type: "boolean"}}
+	pageParams = []openapi.Parameter{
+		{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer",
+			Minimum: float(1), Maximum: float(maxPageLimit)}},
+		{Name: "offset", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: float(0)}},
+	}
+	lastEventIdParam = openapi.Parameter{Name: "Last-Event-ID", In: "header",
+		Description: "Resumes a stream after the event with the id",
+		Schema:      &openapi.Schema{Type: "integer", Minimum: float(0)}}
+	sinceParam = openapi.Parameter{Name: "since", In: "query",
+		Description: "Token of the last sync or page, all records are returned without it",
+		Schema:      &openapi.Schema{Type: "string"}}
+	idempotencyKeyParam = openapi.Parameter{Name: "Idempotency-Key", In: "header",
+		Description: "Makes a retried request return the response of the first one",
+		Schema:      &openapi.Schema{Type: "string"}}
+)
+
+var statusOk = map[string]interface{}{"status": "ok"}
+
+// apiOperations lists every route of InitRoutes, a test keeps them in sync.
+var apiOperations = []apiOperation{
+	{method: "POST", path: "/auth/sign-up", tag: "auth", summary: "Create a user",
+		request: domain.User{}, status: 200, response: map[string]interface{}{"id": 0}},
+	{method: "POST", path: "/auth/sign-in", tag: "auth", summary: "Sign in and set the refresh token cookie",
+		request: signInInput{}, status: 200, response: map[string]interface{}{"tokens": service.Tokens{}}},
+	{method: "POST", path: "/auth/refresh", tag: "auth", summary: "Exchange the refresh token cookie for new tokens",
+		status: 200, response: map[string]interface{}{"tokens": service.Tokens{}}},
+	{method: "DELETE", path: "/auth/logout", tag: "auth", summary: "End the session of the refresh token cookie",
+		status: 200, response: statusOk},
+	{method: "DELETE", path: "/auth/logout-all", tag: "auth", summary: "End all sessions of the user",
+		status: 200, response: statusOk},
+
+	{method: "POST", path: "/graphql", tag: "graphql", summary: "Run a GraphQL query or mutation over lists and items",
+		request: graph.Request{}, status: 200, response: graphqlResult{}},
+
+	{method: "POST", path: "/api/lists/", tag: "lists", summary: "Create a list",
+		request: domain.TodoList{}, status: 201, response: 0},
+	{method: "GET", path: "/api/lists/", tag: "lists", summary: "Get the lists of the user",
+		params: []openapi.Parameter{archivedParam},
+		status: 200, response: map[string]interface{}{"todoLists": []domain.TodoList{}}},
+	{method: "GET", path: "/api/lists/:id", tag: "lists", summary: "Get a list",
+		params: []openapi.Parameter{ifNoneMatchParam},
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "PUT", path: "/api/lists/:id", tag: "lists", summary: "Replace a list",
+		params: []openapi.Parameter{ifMatchParam}, request: domain.ReplaceTodoList{},
+		status: 201, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "PATCH", path: "/api/lists/:id", tag: "lists", summary: "Patch a list",
+		params: []openapi.Parameter{ifMatchParam}, request: patchBody{domain.ReplaceTodoList{}},
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "DELETE", path: "/api/lists/:id", tag: "lists", summary: "Move a list to the trash",
+		params: []openapi.Parameter{ifMatchParam}, status: 201, response: statusOk},
+	{method: "POST", path: "/api/lists/:id/archive", tag: "lists", summary: "Archive a list",
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "DELETE", path: "/api/lists/:id/archive", tag: "lists", summary: "Unarchive a list",
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "POST", path: "/api/lists/:id/pin", tag: "lists", summary: "Pin a list",
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "DELETE", path: "/api/lists/:id/pin", tag: "lists", summary: "Unpin a list",
+		status: 200, response: map[string]interface{}{"todoList": domain.TodoList{}}},
+	{method: "POST", path: "/api/lists/:id/duplicate", tag: "lists", summary: "Duplicate a list with its items",
+		request: domain.DuplicateTodoList{}, status: 201, response: 0},
+	{method: "POST", path: "/api/lists/:id/template", tag: "templates", summary: "Save a list as a template",
+		request: domain.CreateListTemplate{}, status: 201, response: 0},
+	{method: "GET", path: "/api/lists/:id/statuses", tag: "statuses", summary: "Get the statuses of a list",
+		status: 200, response: map[string]interface{}{"statuses": []domain.Status{}}},
+	{method: "POST", path: "/api/lists/:id/statuses", tag: "statuses", summary: "Create a status",
+		request: domain.Status{}, status: 201, response: 0},
+	{method: "GET", path: "/api/lists/:id/board", tag: "statuses", summary: "Get the items of a list by status",
+		status: 200, response: map[string]interface{}{"board": domain.Board{}}},
+	{method: "GET", path: "/api/lists/:id/members", tag: "assignees", summary: "Get the users of a list",
+		status: 200, response: map[string]interface{}{"members": []domain.Assignee{}}},
+	{method: "GET", path: "/api/lists/:id/activity", tag: "activity", summary: "Get the activity of a list",
+		params: pageParams, status: 200, response: domain.ActivityPage{}},
+	{method: "POST", path: "/api/lists/:id/webhooks", tag: "webhooks", summary: "Register a webhook on a list",
+		request: domain.Webhook{}, status: 201, response: map[string]interface{}{"webhook": domain.Webhook{}}},
+	{method: "GET", path: "/api/lists/:id/webhooks", tag: "webhooks", summary: "Get the webhooks of a list",
+		status: 200, response: map[string]interface{}{"webhooks": []domain.Webhook{}}},
+	{method: "POST", path: "/api/lists/:id/items/", tag: "items", summary: "Create an item",
+		request: domain.TodoItem{}, status: 201, response: 0},
+	{method: "GET", path: "/api/lists/:id/items/", tag: "items", summary: "Get the items of a list",
+		status: 200, response: map[string]interface{}{"todoItems": []domain.TodoItem{}}},
+	{method: "POST", path: "/api/lists/:id/items/bulk", tag: "items", summary: "Apply operations to items atomically",
+		request: domain.BulkItemOperations{}, status: 200,
+		response: map[string]interface{}{"results": []domain.BulkItemResult{}}},
+
+	{method: "GET", path: "/api/items/assigned", tag: "assignees", summary: "Get the items assigned to the user",
+		status: 200, response: map[string]interface{}{"todoItems": []domain.AssignedTodoItem{}}},
+	{method: "GET", path: "/api/items/:id", tag: "items", summary: "Get an item",
+		params: []openapi.Parameter{ifNoneMatchParam},
+		status: 200, response: map[string]interface{}{"todoItem": domain.TodoItem{}}},
+	{method: "PUT", path: "/api/items/:id", tag: "items", summary: "Replace an item",
+		params: []openapi.Parameter{ifMatchParam}, request: domain.ReplaceTodoItem{},
+		status: 201, response: map[string]interface{}{"todoItem": domain.TodoItem{}}},
+	{method: "PATCH", path: "/api/items/:id", tag: "items", summary: "Patch an item",
+		params: []openapi.Parameter{ifMatchParam}, request: patchBody{domain.ReplaceTodoItem{}},
+		status: 200, response: map[string]interface{}{"todoItem": domain.TodoItem{}}},
+	{method: "DELETE", path: "/api/items/:id", tag: "items", summary: "Move an item to the trash",
+		params: []openapi.Parameter{ifMatchParam}, status: 200, response: statusOk},
+	{method: "POST", path: "/api/items/:id/move", tag: "items", summary: "Move an item to another list",
+		request: domain.TodoItemDestination{}, status: 200,
+		response: map[string]interface{}{"todoItem": domain.TodoItem{}}},
+	{method: "POST", path: "/api/items/:id/copy", tag: "items", summary: "Copy an item to a list",
+		request: domain.TodoItemDestination{}, status: 201, response: 0},
+	{method: "PUT", path: "/api/items/:id/status", tag: "statuses", summary: "Set the status of an item",
+		request: domain.TodoItemStatus{}, status: 200,
+		response: map[string]interface{}{"todoItem": domain.TodoItem{}}},
+	{method: "GET", path: "/api/items/:id/assignees", tag: "assignees", summary: "Get the assignees of an item",
+		status: 200, response: map[string]interface{}{"assignees": []domain.Assignee{}}},
+	{method: "PUT", path: "/api/items/:id/assignees", tag: "assignees", summary: "Replace the assignees of an item",
+		request: domain.TodoItemAssignees{}, status: 200,
+		response: map[string]interface{}{"assignees": []domain.Assignee{}}},
+	{method: "GET", path: "/api/items/:id/comments", tag: "comments", summary: "Get the comments on an item",
+		params: pageParams, status: 200, response: domain.CommentsPage{}},
+	{method: "POST", path: "/api/items/:id/comments", tag: "comments", summary: "Comment on an item",
+		request: domain.CommentInput{}, status: 201, response: map[string]interface{}{"comment": domain.Comment{}}},
+	{method: "GET", path: "/api/items/:id/attachments", tag: "attachments", summary: "Get the attachments of an item",
+		status: 200, response: map[string]interface{}{"attachments": []domain.Attachment{}}},
+	{method: "POST", path: "/api/items/:id/attachments", tag: "attachments", summary: "Upload an attachment",
+		request: multipartFile{}, status: 201, response: map[string]interface{}{"attachment": domain.Attachment{}}},
+	{method: "GET", path: "/api/items/:id/history", tag: "activity", summary: "Get the history of an item",
+		params: pageParams, status: 200, response: domain.ActivityPage{}},
+
+	{method: "PUT", path: "/api/comments/:id", tag: "comments", summary: "Edit a comment",
+		request: domain.CommentInput{}, status: 200, response: map[string]interface{}{"comment": domain.Comment{}}},
+	{method: "DELETE", path: "/api/comments/:id", tag: "comments", summary: "Delete a comment",
+		status: 200, response: statusOk},
+
+	{method: "GET", path: "/api/attachments/:id/content", tag: "attachments", summary: "Download an attachment",
+		status: 200, response: binaryContent{}},
+	{method: "DELETE", path: "/api/attachments/:id", tag: "attachments", summary: "Delete an attachment",
+		status: 200, response: statusOk},
+
+	{method: "PUT", path: "/api/statuses/:id", tag: "statuses", summary: "Update a status",
+		request: domain.UpdateStatus{}, status: 200, response: map[string]interface{}{"status": domain.Status{}}},
+	{method: "DELETE", path: "/api/statuses/:id", tag: "statuses", summary: "Delete a status",
+		status: 200, response: statusOk},
+
+	{method: "GET", path: "/api/templates/", tag: "templates", summary: "Get the templates of the user",
+		status: 200, response: map[string]interface{}{"templates": []domain.ListTemplate{}}},
+	{method: "GET", path: "/api/templates/:id", tag: "templates", summary: "Get a template with its items",
+		status: 200, response: map[string]interface{}{"template": domain.ListTemplate{}}},
+	{method: "DELETE", path: "/api/templates/:id", tag: "templates", summary: "Delete a template",
+		status: 200, response: statusOk},
+	{method: "POST", path: "/api/templates/:id/instantiate", tag: "templates", summary: "Create a list from a template",
+		request: domain.InstantiateListTemplate{}, status: 201, response: 0},
+
+	{method: "GET", path: "/api/webhooks/:id", tag: "webhooks", summary: "Get a webhook",
+		status: 200, response: map[string]interface{}{"webhook": domain.Webhook{}}},
+	{method: "PUT", path: "/api/webhooks/:id", tag: "webhooks", summary: "Update a webhook",
+		request: domain.UpdateWebhook{}, status: 200, response: map[string]interface{}{"webhook": domain.Webhook{}}},
+	{method: "DELETE", path: "/api/webhooks/:id", tag: "webhooks", summary: "Delete a webhook",
+		status: 200, response: statusOk},
+	{method: "GET", path: "/api/webhooks/:id/deliveries", tag: "webhooks", summary: "Get the delivery log of a webhook",
+		params: pageParams, status: 200, response: domain.WebhookDeliveryPage{}},
+	{method: "POST", path: "/api/deliveries/:id/redeliver", tag: "webhooks", summary: "Send a delivery again",
+		status: 202, response: map[string]interface{}{"delivery": domain.WebhookDelivery{}}},
+	{method: "GET", path: "/api/sync", tag: "sync", summary: "Get the changes to lists and items since a sync",
+		params: []openapi.Parameter{sinceParam}, status: 200, response: domain.SyncChanges{}},
+	{method: "POST", path: "/api/sync", tag: "sync", summary: "Apply changes made offline and get the changes since a sync",
+		request: domain.SyncRequest{}, status: 200,
+		response: map[string]interface{}{"results": []domain.SyncResult{}, "changes": domain.SyncChanges{}}},
+
+	{method: "GET", path: "/api/stream", tag: "activity", summary: "Stream the activity on the lists of the user",
+		params: []openapi.Parameter{lastEventIdParam}, status: 200, response: eventStream{domain.Activity{}}},
+
+	{method: "POST", path: "/api/undo", tag: "activity", summary: "Undo the last operation of the user",
+		status: 200, response: map[string]interface{}{"activity": domain.Activity{}}},
+	{method: "POST", path: "/api/undo/:id", tag: "activity", summary: "Undo an operation",
+		status: 200, response: map[string]interface{}{"activity": domain.Activity{}}},
+
+	{method: "GET", path: "/api/trash/", tag: "trash", summary: "Get the lists and items in the trash",
+		status: 200, response: map[string]interface{}{"trash": domain.Trash{}}},
+	{method: "POST", path: "/api/trash/lists/:id/restore", tag: "trash", summary: "Restore a list from the trash",
+		status: 200, response: statusOk},
+	{method: "DELETE", path: "/api/trash/lists/:id", tag: "trash", summary: "Delete a list for good",
+		status: 200, response: statusOk},
+	{method: "POST", path: "/api/trash/items/:id/restore", tag: "trash", summary: "Restore an item from the trash",
+		status: 200, response: statusOk},
+	{method: "DELETE", path: "/api/trash/items/:id", tag: "trash", summary: "Delete an item for good",
+		status: 200, response: statusOk},
+}
+
+// newAPIDocument generates the OpenAPI document of apiOperations.
+func newAPIDocument() *openapi.Document {
+	doc := openapi.NewDocument("Todo API", "1.0.0")
+	schemas := openapi.NewGenerator()
+	problemSchema := schemas.Schema(problem{})
+
+	for _, apiOp := range apiOperations {
+		operation := &openapi.Operation{
+			OperationId: operationId(apiOp.method, apiOp.path),
+			Summary:     apiOp.summary,
+			Tags:        []string{apiOp.tag},
+			Responses: map[string]*openapi.Response{
+				"default": {
+					Description: "Error",
+					Content:     map[string]openapi.MediaType{problemContentType: {Schema: problemSchema}},
+				},
+			},
+		}
+
+		_, names := openapi.PathTemplate(apiOp.path)
+		for _, name := range names {
+			operation.Parameters = append(operation.Parameters, openapi.Parameter{
+				Name: name, In: "path", Required: true,
+				Schema: &openapi.Schema{Type: "integer", Minimum: float(1)},
+			})
+		}
+		operation.Parameters = append(operation.Parameters, apiOp.params...)
+
+		if !strings.HasPrefix(apiOp.path, "/auth/") {
+			operation.Security = []map[string][]string{{"bearerAuth": {}}}
+		}
+		if strings.HasPrefix(apiOp.path, "/api/") && apiOp.method == http.MethodPost {
+			operation.Parameters = append(operation.Parameters, idempotencyKeyParam)
+		}
+
+		if apiOp.request != nil {
+			operation.RequestBody = &openapi.RequestBody{Required: true, Content: requestContent(schemas, apiOp.request)}
+		}
+
+		response := &openapi.Response{Description: http.StatusText(apiOp.status)}
+		switch body := apiOp.response.(type) {
+		case binaryContent:
+			response.Content = map[string]openapi.MediaType{"application/octet-stream": {Schema: openapi.Binary()}}
+		case eventStream:
+			response.Content = map[string]openapi.MediaType{eventStreamType: {Schema: schemas.Schema(body.value)}}
+		default:
+			response.Content = map[string]openapi.MediaType{echo.MIMEApplicationJSON: {Schema: schemas.Schema(apiOp.response)}}
+		}
+		operation.Responses[strconv.Itoa(apiOp.status)] = response
+
+		doc.AddOperation(apiOp.method, apiOp.path, operation)
+	}
+
+	doc.Components.Schemas = schemas.Schemas()
+	doc.Components.SecuritySchemes = map[string]openapi.SecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+	}
+	return doc
+}
+
+func requestContent(schemas *openapi.Generator, request interface{}) map[string]openapi.MediaType {
+	switch request := request.(type) {
+	case patchBody:
+		return map[string]openapi.MediaType{
+			patch.MergePatchType: {Schema: schemas.Schema(request.value)},
+			patch.JSONPatchType:  {Schema: schemas.Schema([]jsonPatchOperation{})},
+		}
+	case multipartFile:
+		return map[string]openapi.MediaType{
+			echo.MIMEMultipartForm: {Schema: openapi.Object(map[string]*openapi.Schema{"file": openapi.Binary()})},
+		}
+	}
+	return map[string]openapi.MediaType{echo.MIMEApplicationJSON: {Schema: schemas.Schema(request)}}
+}
+
+// operationId names an operation after its route, e.g. "POST /api/lists/:id/pin"
+// becomes "postApiListsIdPin".
+func operationId(method string, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		for _, word := range strings.Split(strings.TrimPrefix(segment, ":"), "-") {
+			if word != "" {
+				id += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return id
+}
+
+func float(n float64) *float64 {
+	return &n
+}
+
+func (h *Handler) getOpenAPI(c echo.Context) error {
+	return c.JSON(200, h.apiDocument)
+}
+
+func (h *Handler) getDocs(c echo.Context) error {
+	return c.HTMLBlob(200, docsPage)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIDocument_coversRoutes(t *testing.T) {
+	handler := NewHandler(&service.Service{})
+	router := handler.InitRoutes()
+
+	undocumented := map[string]bool{"/openapi.json": true, "/docs": true}
+	routes := 0
+	for _, route := range router.Routes() {
+		if route.Method == echo.RouteNotFound || undocumented[route.Path] {
+			continue
+		}
+		routes++
+		assert.NotNil(t, handler.apiDocument.Operation(route.Method, route.Path),
+			"route %s %s is not documented in apiOperations", route.Method, route.Path)
+	}
+
+	assert.Equal(t, routes, len(apiOperations), "apiOperations documents routes that do not exist")
+}
+
+func TestHandler_getOpenAPI(t *testing.T) {
+	router := NewHandler(&service.Service{}).InitRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.Contains(t, doc.Paths["/api/lists/{id}"], "patch")
+	assert.Contains(t, doc.Components.Schemas, "TodoList")
+}
+
+func TestHandler_getDocs(t *testing.T) {
+	router := NewHandler(&service.Service{}).InitRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+
+	// Assets served by the app must be found, the others are pinned to a
+	// version of swagger-ui-dist.
+	refs := regexp.MustCompile(`(?:href|src)="([^"]*)"`).FindAllStringSubmatch(rec.Body.String(), -1)
+	assert.NotEmpty(t, refs)
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref[1], "/") {
+			assert.Regexp(t, `^https://unpkg\.com/swagger-ui-dist@[0-9.]+/`, ref[1])
+			continue
+		}
+		req := httptest.NewRequest(http.MethodGet, ref[1], nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, 200, rec.Code, "the docs page loads %s", ref[1])
+	}
+}
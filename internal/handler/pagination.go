@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// getPagination reads the limit and offset query parameters.
+func getPagination(c echo.Context) (int, int, error) {
+	var err error
+
+	limit := defaultPageLimit
+	if c.QueryParam("limit") != "" {
+		limit, err = strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, 0, newErrorResponse(400, "Limit must be an integer from 1 to 100")
+		}
+	}
+	offset := 0
+	if c.QueryParam("offset") != "" {
+		offset, err = strconv.Atoi(c.QueryParam("offset"))
+		if err != nil || offset < 0 {
+			return 0, 0, newErrorResponse(400, "Offset must be a non-negative integer")
+		}
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/IvanMeln1k/go-todo-app/pkg/patch"
+	"github.com/labstack/echo/v4"
+)
+
+// PATCH reads the resource, applies the patch to its replacement document
+// and replaces the resource on condition that it has not changed meanwhile.
+// Without If-Match a concurrent change just makes it try again.
+const maxPatchAttempts = 3
+
+var errPatchTestFailed = domain.NewConflictError("patch_test_failed", "Patch test failed")
+
+type patchFunc func(doc []byte, patch []byte) ([]byte, error)
+
+func getPatchFunc(c echo.Context) (patchFunc, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case patch.MergePatchType:
+		return patch.MergePatch, nil
+	case patch.JSONPatchType:
+		return patch.ApplyJSONPatch, nil
+	}
+
+	c.Response().Header().Set("Accept-Patch", patch.MergePatchType+", "+patch.JSONPatchType)
+	return nil, newErrorResponse(415, "Unsupported patch media type")
+}
+
+// applyPatch patches the JSON document of source and decodes the result into
+// target, which must be a pointer to a zero value.
+func (h *Handler) applyPatch(c echo.Context, apply patchFunc, body []byte, source interface{}, target interface{}) error {
+	doc, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	patched, err := apply(doc, body)
+	if err != nil {
+		if errors.Is(err, patch.ErrTestFailed) {
+			return errPatchTestFailed
+		} else if errors.Is(err, patch.ErrPathNotFound) {
+			return domain.NewValidationError("patch_path_not_found", err.Error())
+		}
+		return newErrorResponse(400, err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(target); err != nil {
+		return domain.NewValidationError("invalid_patch_result", err.Error())
+	}
+	if err = c.Validate(target); err != nil {
+		return h.validationError(c, err)
+	}
+	return nil
+}
+
+func (h *Handler) patchList(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "Bad request")
+	}
+
+	apply, err := getPatchFunc(c)
+	if err != nil {
+		return err
+	}
+	version, err := getIfMatch(c)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+
+	for attempt := 1; ; attempt++ {
+		todoList, err := h.services.TodoList.GetById(userId, todoListId)
+		if err != nil {
+			return err
+		}
+		if version != nil && *version != todoList.Version {
+			return service.ErrVersionMismatch
+		}
+
+		var replaceTodoList domain.ReplaceTodoList
+		if err = h.applyPatch(c, apply, body, todoList.Replacement(), &replaceTodoList); err != nil {
+			return err
+		}
+		replaceTodoList.Version = &todoList.Version
+
+		todoList, err = h.services.TodoList.Replace(userId, todoListId, replaceTodoList)
+		if err != nil {
+			if errors.Is(err, service.ErrVersionMismatch) && version == nil && attempt < maxPatchAttempts {
+				continue
+			}
+			return err
+		}
+
+		setETag(c, todoList.Version)
+
+		return c.JSON(200, map[string]interface{}{
+			"todoList": todoList,
+		})
+	}
+}
+
+func (h *Handler) patchItem(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	apply, err := getPatchFunc(c)
+	if err != nil {
+		return err
+	}
+	version, err := getIfMatch(c)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+
+	for attempt := 1; ; attempt++ {
+		todoItem, err := h.services.TodoItem.GetById(userId, todoItemId)
+		if err != nil {
+			return err
+		}
+		if version != nil && *version != todoItem.Version {
+			return service.ErrVersionMismatch
+		}
+
+		var replaceTodoItem domain.ReplaceTodoItem
+		if err = h.applyPatch(c, apply, body, todoItem.Replacement(), &replaceTodoItem); err != nil {
+			return err
+		}
+		replaceTodoItem.Version = &todoItem.Version
+
+		todoItem, err = h.services.TodoItem.Replace(userId, todoItemId, replaceTodoItem)
+		if err != nil {
+			if errors.Is(err, service.ErrVersionMismatch) && version == nil && attempt < maxPatchAttempts {
+				continue
+			}
+			return err
+		}
+
+		setETag(c, todoItem.Version)
+
+		return c.JSON(200, map[string]interface{}{
+			"todoItem": todoItem,
+		})
+	}
+}
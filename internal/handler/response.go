@@ -1,11 +1,105 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
+const problemContentType = "application/problem+json"
+
+var kindStatuses = map[domain.ErrorKind]int{
+	domain.KindNotFound:             http.StatusNotFound,
+	domain.KindConflict:             http.StatusConflict,
+	domain.KindForbidden:            http.StatusForbidden,
+	domain.KindValidation:           http.StatusUnprocessableEntity,
+	domain.KindUnauthorized:         http.StatusUnauthorized,
+	domain.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	domain.KindTooLarge:             http.StatusRequestEntityTooLarge,
+	domain.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// newErrorResponse is for errors the handlers find themselves, like
+// malformed parameters. Their code is derived from the status.
 func newErrorResponse(statusCode int, message string) error {
 	logrus.Error(message)
 	return echo.NewHTTPError(statusCode, message)
 }
+
+// validationError reports the fields rejected by the validator, with
+// messages in the language the client asked for.
+func (h *Handler) validationError(c echo.Context, err error) error {
+	fieldErrs, ok := h.validator.FieldErrors(err, c.Request().Header.Get("Accept-Language"))
+	if !ok {
+		return newErrorResponse(400, err.Error())
+	}
+
+	fields := make([]domain.FieldError, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		fields = append(fields, domain.FieldError{
+			Field:   fieldErr.Field,
+			Rule:    fieldErr.Rule,
+			Param:   fieldErr.Param,
+			Message: fieldErr.Message,
+		})
+	}
+	return domain.ErrValidation.WithFields(fields...)
+}
+
+// errorHandler renders errors as RFC 7807 problem details. The code member
+// is stable, clients switch on it rather than on the detail.
+func errorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, code, detail := http.StatusInternalServerError, "internal_error", "Internal server error"
+	problem := map[string]interface{}{}
+
+	var domainErr *domain.Error
+	var httpErr *echo.HTTPError
+	if errors.As(err, &domainErr) {
+		status, code, detail = kindStatuses[domainErr.Kind], domainErr.Code, domainErr.Message
+		if len(domainErr.Fields) > 0 {
+			problem["errors"] = domainErr.Fields
+		}
+	} else if errors.As(err, &httpErr) {
+		status, code, detail = httpErr.Code, statusCode(httpErr.Code), fmt.Sprint(httpErr.Message)
+	} else {
+		logrus.Error(err)
+	}
+
+	var detailed interface{ Details() map[string]interface{} }
+	if errors.As(err, &detailed) {
+		for name, value := range detailed.Details() {
+			problem[name] = value
+		}
+	}
+
+	problem["type"] = "about:blank"
+	problem["title"] = http.StatusText(status)
+	problem["status"] = status
+	problem["detail"] = detail
+	problem["code"] = code
+	problem["instance"] = c.Request().URL.Path
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+		err = c.JSON(status, problem)
+	}
+	if err != nil {
+		logrus.Error(err)
+	}
+}
+
+// statusCode turns a status into a code, e.g. 404 into "not_found".
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	testTable := []struct {
+		name                string
+		err                 error
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:               "wrapped domain error",
+			err:                fmt.Errorf("restore: %w", domain.ErrListNotFound),
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"list_not_found","detail":"List not found","instance":"/lists/1",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "error with details",
+			err: &domain.UndoConflictError{ActivityId: 7, Conflicts: []domain.UndoConflict{
+				{Field: "title", Expected: "new", Actual: "newer"},
+			}},
+			expectedStatusCode: 409,
+			expectedRequestBody: `{"activityId":7,"code":"record_changed","conflicts":[` +
+				`{"field":"title","expected":"new","actual":"newer"}],` +
+				`"detail":"Record has changed since the operation","instance":"/lists/1",` +
+				`"status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+		{
+			name:               "unknown error",
+			err:                errors.New("connection refused"),
+			expectedStatusCode: 500,
+			expectedRequestBody: `{"code":"internal_error","detail":"Internal server error","instance":"/lists/1",` +
+				`"status":500,"title":"Internal Server Error","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = errorHandler
+			e.GET("/lists/:id", func(c echo.Context) error {
+				return testCase.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/lists/1", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, problemContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
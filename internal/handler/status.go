@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) getAllStatuses(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	statuses, err := h.services.Status.GetAll(userId, todoListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"statuses": statuses,
+	})
+}
+
+func (h *Handler) createStatus(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	var status domain.Status
+	if err = c.Bind(&status); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&status); err != nil {
+		return h.validationError(c, err)
+	}
+
+	statusId, err := h.services.Status.Create(userId, todoListId, status)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, statusId)
+}
+
+func (h *Handler) updateStatus(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	statusId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "StatusId is no integer value")
+	}
+
+	var updateStatus domain.UpdateStatus
+	if err = c.Bind(&updateStatus); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = updateStatus.Validate(); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&updateStatus); err != nil {
+		return h.validationError(c, err)
+	}
+
+	status, err := h.services.Status.Update(userId, statusId, updateStatus)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": status,
+	})
+}
+
+func (h *Handler) deleteStatus(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	statusId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "StatusId is no integer value")
+	}
+
+	err = h.services.Status.Delete(userId, statusId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+func (h *Handler) setItemStatus(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoItemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoItemId is no integer value")
+	}
+
+	var itemStatus domain.TodoItemStatus
+	if err = c.Bind(&itemStatus); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&itemStatus); err != nil {
+		return h.validationError(c, err)
+	}
+
+	todoItem, err := h.services.Status.SetItemStatus(userId, todoItemId, itemStatus.StatusId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"todoItem": todoItem,
+	})
+}
+
+func (h *Handler) getBoard(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	board, err := h.services.Status.GetBoard(userId, todoListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"board": board,
+	})
+}
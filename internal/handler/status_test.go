@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_updateStatus(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStatus)
+
+	name := "review"
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"name":"review"}`,
+			mockBehavior: func(s *mock_service.MockStatus) {
+				s.EXPECT().Update(1, 3, domain.UpdateStatus{Name: &name}).
+					Return(domain.Status{Id: 3, ListId: 2, Name: "review", Position: 2}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"status":{"id":3,"listId":2,"name":"review","position":2,"isDone":false}}` +
+				"\n",
+		},
+		{
+			name:               "no values",
+			inputBody:          `{}`,
+			mockBehavior:       func(s *mock_service.MockStatus) {},
+			expectedStatusCode: 400,
+			expectedRequestBody: `{"code":"bad_request","detail":"update struct has no values","instance":"/statuses/3",` +
+				`"status":400,"title":"Bad Request","type":"about:blank"}` + "\n",
+		},
+		{
+			name:      "status not found",
+			inputBody: `{"name":"review"}`,
+			mockBehavior: func(s *mock_service.MockStatus) {
+				s.EXPECT().Update(1, 3, domain.UpdateStatus{Name: &name}).Return(domain.Status{}, domain.ErrStatusNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"status_not_found","detail":"Status not found","instance":"/statuses/3",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			status := mock_service.NewMockStatus(c)
+			testCase.mockBehavior(status)
+
+			handler := NewHandler(&service.Service{Status: status})
+
+			e := echo.New()
+			e.PUT("/statuses/:id", handler.updateStatus, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPut, "/statuses/3", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_setItemStatus(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStatus)
+
+	statusId := 4
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"statusId":4}`,
+			mockBehavior: func(s *mock_service.MockStatus) {
+				s.EXPECT().SetItemStatus(1, 2, 4).
+					Return(domain.TodoItem{Id: 2, Title: "item", Done: true, StatusId: &statusId, Version: 3}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"todoItem":{"id":2,"title":"item","description":null,"Done":true,"statusId":4,` +
+				`"version":3}}` + "\n",
+		},
+		{
+			name:               "missing status",
+			inputBody:          `{}`,
+			mockBehavior:       func(s *mock_service.MockStatus) {},
+			expectedStatusCode: 422,
+			expectedRequestBody: `{"code":"validation_failed","detail":"Validation failed","errors":[` +
+				`{"field":"statusId","rule":"required","message":"statusId is a required field"}],` +
+				`"instance":"/items/2/status","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name:      "status of another list",
+			inputBody: `{"statusId":4}`,
+			mockBehavior: func(s *mock_service.MockStatus) {
+				s.EXPECT().SetItemStatus(1, 2, 4).Return(domain.TodoItem{}, domain.ErrStatusNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedRequestBody: `{"code":"status_not_found","detail":"Status not found","instance":"/items/2/status",` +
+				`"status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			status := mock_service.NewMockStatus(c)
+			testCase.mockBehavior(status)
+
+			handler := NewHandler(&service.Service{Status: status})
+
+			e := echo.New()
+			e.PUT("/items/:id/status", handler.setItemStatus, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("userId", 1)
+					return next(c)
+				}
+			})
+			e.Validator = validate.NewCustomValidator()
+			e.HTTPErrorHandler = errorHandler
+
+			req := httptest.NewRequest(http.MethodPut, "/items/2/status", strings.NewReader(testCase.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, rec.Code)
+			assert.Equal(t, testCase.expectedRequestBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_getBoard(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	status := mock_service.NewMockStatus(c)
+	status.EXPECT().GetBoard(1, 2).Return(domain.Board{
+		TodoList: domain.TodoList{Id: 2, Title: "list", Version: 1},
+		Columns: []domain.BoardColumn{
+			{Status: domain.Status{Id: 3, ListId: 2, Name: "todo", Position: 1},
+				Items: []domain.TodoItem{{Id: 5, Title: "item", Version: 1}}},
+			{Status: domain.Status{Id: 4, ListId: 2, Name: "done", Position: 2, IsDone: true},
+				Items: []domain.TodoItem{}},
+		},
+	}, nil)
+
+	handler := NewHandler(&service.Service{Status: status})
+
+	e := echo.New()
+	e.GET("/lists/:id/board", handler.getBoard, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userId", 1)
+			return next(c)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/lists/2/board", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, `{"board":{"todoList":{"id":2,"title":"list","description":null,"archived":false,"pinned":false,`+
+		`"version":1},"columns":[{"id":3,"listId":2,"name":"todo","position":1,"isDone":false,"items":[`+
+		`{"id":5,"title":"item","description":null,"Done":false,"statusId":null,"version":1}]},`+
+		`{"id":4,"listId":2,"name":"done","position":2,"isDone":true,"items":[]}]}}`+"\n", rec.Body.String())
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamHeartbeat is how often an idle stream sends a comment, so proxies
+// keep the connection open.
+const streamHeartbeat = 30 * time.Second
+
+const eventStreamType = "text/event-stream"
+
+// stream pushes the activity on the lists of the user as server-sent events.
+// The event id is the id of the activity and the event name its action, so a
+// client that reconnects with Last-Event-ID gets what it missed first.
+func (h *Handler) stream(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	lastEventId := 0
+	if header := c.Request().Header.Get("Last-Event-ID"); header != "" {
+		lastEventId, err = strconv.Atoi(header)
+		if err != nil || lastEventId < 0 {
+			return newErrorResponse(400, "Invalid Last-Event-ID header")
+		}
+	}
+
+	ctx := c.Request().Context()
+	activities, err := h.services.Events.Subscribe(ctx, userId, lastEventId)
+	if err != nil {
+		return err
+	}
+
+	// A stream outlives the write timeout of the server.
+	err = http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, eventStreamType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	c.Response().WriteHeader(200)
+	c.Response().Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case activity, ok := <-activities:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(activity)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(c.Response(), "id: %d\nevent: %s\ndata: %s\n\n", activity.Id, activity.Action, data)
+			if err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Response(), ": heartbeat\n\n"); err != nil {
+				return err
+			}
+		}
+		c.Response().Flush()
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) getSyncChanges(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	changes, err := h.services.Sync.GetChanges(userId, c.QueryParam("since"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, changes)
+}
+
+func (h *Handler) sync(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	var request domain.SyncRequest
+	if err = c.Bind(&request); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&request); err != nil {
+		return h.validationError(c, err)
+	}
+	for i, change := range request.Changes {
+		if err = change.Validate(); err != nil {
+			return domain.ErrValidation.WithFields(domain.FieldError{
+				Field:   fmt.Sprintf("changes[%d]", i),
+				Rule:    "change",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	results, changes, err := h.services.Sync.Apply(userId, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"results": results,
+		"changes": changes,
+	})
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) createTemplate(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	var createTemplate domain.CreateListTemplate
+	if err = c.Bind(&createTemplate); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&createTemplate); err != nil {
+		return h.validationError(c, err)
+	}
+
+	templateId, err := h.services.Template.Create(userId, todoListId, createTemplate)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, templateId)
+}
+
+func (h *Handler) getAllTemplates(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	templates, err := h.services.Template.GetAll(userId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"templates": templates,
+	})
+}
+
+func (h *Handler) getTemplateById(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TemplateId is no integer value")
+	}
+
+	template, err := h.services.Template.GetById(userId, templateId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"template": template,
+	})
+}
+
+func (h *Handler) deleteTemplate(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TemplateId is no integer value")
+	}
+
+	err = h.services.Template.Delete(userId, templateId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+func (h *Handler) instantiateTemplate(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TemplateId is no integer value")
+	}
+
+	var instantiate domain.InstantiateListTemplate
+	if err = c.Bind(&instantiate); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&instantiate); err != nil {
+		return h.validationError(c, err)
+	}
+
+	todoListId, err := h.services.Template.Instantiate(userId, templateId, instantiate)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, todoListId)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handler) createWebhook(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	var input domain.Webhook
+	if err = c.Bind(&input); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&input); err != nil {
+		return h.validationError(c, err)
+	}
+
+	webhook, err := h.services.Webhook.Create(userId, todoListId, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(201, map[string]interface{}{
+		"webhook": webhook,
+	})
+}
+
+func (h *Handler) getAllWebhooks(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	todoListId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "TodoListId is no integer value")
+	}
+
+	webhooks, err := h.services.Webhook.GetAll(userId, todoListId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"webhooks": webhooks,
+	})
+}
+
+func (h *Handler) getWebhookById(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "WebhookId is no integer value")
+	}
+
+	webhook, err := h.services.Webhook.GetById(userId, webhookId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"webhook": webhook,
+	})
+}
+
+func (h *Handler) updateWebhook(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "WebhookId is no integer value")
+	}
+
+	var input domain.UpdateWebhook
+	if err = c.Bind(&input); err != nil {
+		return newErrorResponse(400, err.Error())
+	}
+	if err = c.Validate(&input); err != nil {
+		return h.validationError(c, err)
+	}
+
+	webhook, err := h.services.Webhook.Update(userId, webhookId, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"webhook": webhook,
+	})
+}
+
+func (h *Handler) deleteWebhook(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "WebhookId is no integer value")
+	}
+
+	err = h.services.Webhook.Delete(userId, webhookId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+func (h *Handler) getWebhookDeliveries(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "WebhookId is no integer value")
+	}
+
+	limit, offset, err := getPagination(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.services.Webhook.GetDeliveries(userId, webhookId, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, page)
+}
+
+func (h *Handler) redeliver(c echo.Context) error {
+	userId, err := getUserId(c)
+	if err != nil {
+		return err
+	}
+
+	deliveryId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return newErrorResponse(400, "DeliveryId is no integer value")
+	}
+
+	delivery, err := h.services.Webhook.Redeliver(userId, deliveryId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(202, map[string]interface{}{
+		"delivery": delivery,
+	})
+}
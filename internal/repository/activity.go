@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type ActivityRepository struct {
+	db *sqlx.DB
+}
+
+func NewActivityRepository(db *sqlx.DB) *ActivityRepository {
+	return &ActivityRepository{
+		db: db,
+	}
+}
+
+const activityColumns = `a.id, a.list_id, a.item_id, a.action, a.changes, a.undo_of, ua.id IS NOT NULL AS undone,
+	a.created_at, u.id AS "actor.id", u.name AS "actor.name", u.username AS "actor.username"`
+
+// writeActivity appends an entry to the activity log in the transaction of
+// the mutation it describes, so the log has an entry for every committed
+// change and none for a rolled back one. The entry is announced through the
+// outbox and queued to the webhooks of the list. A second undo of an activity
+// fails with domain.ErrAlreadyUndone.
+func writeActivity(tx sqlx.Ext, activity domain.Activity) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(`INSERT INTO %s (list_id, item_id, user_id, action, changes, undo_of)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, activitiesTable)
+	err := tx.QueryRowx(query, activity.ListId, activity.ItemId, activity.Actor.Id, activity.Action,
+		activity.Changes, activity.UndoOf).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				return 0, domain.ErrAlreadyUndone
+			}
+		}
+		return 0, err
+	}
+
+	err = writeEvent(tx, domain.AggregateActivity, id, activity.Action,
+		domain.ActivityEvent{ActivityId: id, ListId: activity.ListId})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = enqueueDeliveries(tx, id, activity.ListId, activity.Action); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func writeListActivity(tx sqlx.Ext, userId int, todoListId int, action string, changes domain.Changes) error {
+	_, err := writeActivity(tx, domain.Activity{
+		ListId:  todoListId,
+		Actor:   domain.ActivityActor{Id: userId},
+		Action:  action,
+		Changes: changes,
+	})
+	return err
+}
+
+func writeItemActivity(tx sqlx.Ext, userId int, todoListId int, todoItemId int, action string,
+	changes domain.Changes) error {
+	_, err := writeActivity(tx, domain.Activity{
+		ListId:  todoListId,
+		ItemId:  &todoItemId,
+		Actor:   domain.ActivityActor{Id: userId},
+		Action:  action,
+		Changes: changes,
+	})
+	return err
+}
+
+func (r *ActivityRepository) GetById(userId int, activityId int) (domain.Activity, error) {
+	var activity domain.Activity
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.id = $2 AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = a.list_id
+	AND ul.user_id = $1)`, activityColumns, activitiesTable, usersTable, activitiesTable, usersListsTable)
+	err := r.db.Get(&activity, query, userId, activityId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return activity, domain.ErrActivityNotFound
+		}
+		return activity, err
+	}
+
+	return activity, nil
+}
+
+// GetWithMembers returns an activity of any list with the ids of the users
+// who can access the list, so the activity is read once for all streams.
+func (r *ActivityRepository) GetWithMembers(activityId int) (domain.Activity, []int, error) {
+	var activity domain.Activity
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.id = $1`, activityColumns, activitiesTable, usersTable, activitiesTable)
+	err := r.db.Get(&activity, query, activityId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return activity, nil, domain.ErrActivityNotFound
+		}
+		return activity, nil, err
+	}
+
+	userIds := make([]int, 0)
+	query = fmt.Sprintf(`SELECT DISTINCT user_id FROM %s WHERE list_id = $1`, usersListsTable)
+	if err = r.db.Select(&userIds, query, activity.ListId); err != nil {
+		logrus.Error(err)
+		return activity, nil, err
+	}
+
+	return activity, userIds, nil
+}
+
+// GetLastUndoable returns the latest activity of the user with one of the
+// actions that was recorded after since, is not an undo itself and is not
+// undone yet.
+func (r *ActivityRepository) GetLastUndoable(userId int, actions []string, since time.Time) (domain.Activity, error) {
+	var activity domain.Activity
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.user_id = $1 AND a.action = ANY($2) AND a.created_at > $3
+	AND a.undo_of IS NULL AND ua.id IS NULL ORDER BY a.created_at DESC, a.id DESC LIMIT 1`,
+		activityColumns, activitiesTable, usersTable, activitiesTable)
+	err := r.db.Get(&activity, query, userId, pq.Array(actions), since)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return activity, domain.ErrActivityNotFound
+		}
+		return activity, err
+	}
+
+	return activity, nil
+}
+
+// GetSince returns the activity recorded after an activity on the lists the
+// user can access, oldest first.
+func (r *ActivityRepository) GetSince(userId int, activityId int, limit int) ([]domain.Activity, error) {
+	activities := make([]domain.Activity, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.id > $2 AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = a.list_id
+	AND ul.user_id = $1) ORDER BY a.id LIMIT $3`,
+		activityColumns, activitiesTable, usersTable, activitiesTable, usersListsTable)
+	if err := r.db.Select(&activities, query, userId, activityId, limit); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+func (r *ActivityRepository) GetByList(todoListId int, limit int, offset int) ([]domain.Activity, int, error) {
+	return r.getPage("list_id", todoListId, limit, offset)
+}
+
+func (r *ActivityRepository) GetByItem(todoItemId int, limit int, offset int) ([]domain.Activity, int, error) {
+	return r.getPage("item_id", todoItemId, limit, offset)
+}
+
+func (r *ActivityRepository) getPage(column string, id int, limit int, offset int) ([]domain.Activity, int, error) {
+	activities := make([]domain.Activity, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.%s = $1 ORDER BY a.created_at DESC, a.id DESC LIMIT $2 OFFSET $3`,
+		activityColumns, activitiesTable, usersTable, activitiesTable, column)
+	if err := r.db.Select(&activities, query, id, limit, offset); err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	var total int
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = $1`, activitiesTable, column)
+	if err := r.db.Get(&total, query, id); err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	return activities, total, nil
+}
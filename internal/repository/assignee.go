@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type AssigneeRepository struct {
+	db *sqlx.DB
+}
+
+func NewAssigneeRepository(db *sqlx.DB) *AssigneeRepository {
+	return &AssigneeRepository{
+		db: db,
+	}
+}
+
+func (r *AssigneeRepository) GetAll(todoItemId int) ([]domain.Assignee, error) {
+	assignees := make([]domain.Assignee, 0)
+
+	query := fmt.Sprintf(`SELECT u.id, u.name, u.username FROM %s u INNER JOIN %s ia ON ia.user_id = u.id
+	WHERE ia.item_id = $1 ORDER BY u.id`, usersTable, itemsAssigneesTable)
+	err := r.db.Select(&assignees, query, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return assignees, nil
+}
+
+func (r *AssigneeRepository) GetListMembers(todoListId int) ([]domain.Assignee, error) {
+	members := make([]domain.Assignee, 0)
+
+	query := fmt.Sprintf(`SELECT DISTINCT u.id, u.name, u.username FROM %s u INNER JOIN %s ul
+	ON ul.user_id = u.id WHERE ul.list_id = $1 ORDER BY u.id`, usersTable, usersListsTable)
+	err := r.db.Select(&members, query, todoListId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetAllByItems returns the assignees of the items the user has access to,
+// by item. Every requested item has an entry.
+func (r *AssigneeRepository) GetAllByItems(userId int, todoItemIds []int) (map[int][]domain.Assignee, error) {
+	var rows []struct {
+		ItemId int `db:"item_id"`
+		domain.Assignee
+	}
+
+	query := fmt.Sprintf(`SELECT ia.item_id, u.id, u.name, u.username FROM %s u INNER JOIN %s ia
+	ON ia.user_id = u.id WHERE ia.item_id = ANY($2) AND EXISTS (SELECT 1 FROM %s li INNER JOIN %s ul
+	ON ul.list_id = li.list_id WHERE li.item_id = ia.item_id AND ul.user_id = $1) ORDER BY u.id`,
+		usersTable, itemsAssigneesTable, listsItemsTable, usersListsTable)
+	err := r.db.Select(&rows, query, userId, pq.Array(todoItemIds))
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	assignees := make(map[int][]domain.Assignee, len(todoItemIds))
+	for _, todoItemId := range todoItemIds {
+		assignees[todoItemId] = []domain.Assignee{}
+	}
+	for _, row := range rows {
+		assignees[row.ItemId] = append(assignees[row.ItemId], row.Assignee)
+	}
+
+	return assignees, nil
+}
+
+// GetListMembersByLists returns the members of the lists the user has
+// access to, by list. Every requested list has an entry.
+func (r *AssigneeRepository) GetListMembersByLists(userId int, todoListIds []int) (map[int][]domain.Assignee, error) {
+	var rows []struct {
+		ListId int `db:"list_id"`
+		domain.Assignee
+	}
+
+	query := fmt.Sprintf(`SELECT DISTINCT ul.list_id, u.id, u.name, u.username FROM %s u INNER JOIN %s ul
+	ON ul.user_id = u.id WHERE ul.list_id = ANY($2) AND EXISTS (SELECT 1 FROM %s own
+	WHERE own.list_id = ul.list_id AND own.user_id = $1) ORDER BY u.id`,
+		usersTable, usersListsTable, usersListsTable)
+	err := r.db.Select(&rows, query, userId, pq.Array(todoListIds))
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	members := make(map[int][]domain.Assignee, len(todoListIds))
+	for _, todoListId := range todoListIds {
+		members[todoListId] = []domain.Assignee{}
+	}
+	for _, row := range rows {
+		members[row.ListId] = append(members[row.ListId], row.Assignee)
+	}
+
+	return members, nil
+}
+
+func (r *AssigneeRepository) Set(userId int, todoItemId int, userIds []int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// The item's row is locked for the event of the change, which also keeps
+	// the item from moving to another list meanwhile.
+	todoItem, err := lockWritableItem(tx, userId, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	todoListId := todoItem.ListId
+
+	var withoutAccess int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM unnest($2::bigint[]) AS assignee (user_id) WHERE NOT EXISTS
+	(SELECT 1 FROM %s ul WHERE ul.list_id = $1 AND ul.user_id = assignee.user_id)`, usersListsTable)
+	if err = tx.QueryRow(query, todoListId, pq.Array(userIds)).Scan(&withoutAccess); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	if withoutAccess > 0 {
+		return domain.ErrAssigneeHasNoAccess
+	}
+
+	before := make([]int, 0)
+	query = fmt.Sprintf(`DELETE FROM %s WHERE item_id = $1 RETURNING user_id`, itemsAssigneesTable)
+	if err = tx.Select(&before, query, todoItemId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	after := make([]int, 0)
+	query = fmt.Sprintf(`INSERT INTO %s (item_id, user_id) SELECT DISTINCT $1::bigint, unnest($2::bigint[])
+	RETURNING user_id`, itemsAssigneesTable)
+	if err = tx.Select(&after, query, todoItemId, pq.Array(userIds)); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	sort.Ints(before)
+	sort.Ints(after)
+	if !sameValue(before, after) {
+		if err = writeItemEvent(tx, domain.ActivityItemAssigned, todoListId, todoItemId); err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		err = writeItemActivity(tx, userId, todoListId, todoItemId, domain.ActivityItemAssigned, domain.Changes{
+			"assigneeIds": {Old: before, New: after},
+		})
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *AssigneeRepository) GetAssigned(userId int) ([]domain.AssignedTodoItem, error) {
+	todoItems := make([]domain.AssignedTodoItem, 0)
+
+	query := fmt.Sprintf(`SELECT ti.*, li.list_id FROM %s ti INNER JOIN %s ia ON ia.item_id = ti.id
+	INNER JOIN %s li ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id
+	WHERE ia.user_id = $1 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL
+	AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = $1)
+	ORDER BY ti.done, ti.id`, todoItemsTable, itemsAssigneesTable, listsItemsTable, todoListsTable,
+		usersListsTable)
+	err := r.db.Select(&todoItems, query, userId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return todoItems, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentRepository struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentRepository(db *sqlx.DB) *AttachmentRepository {
+	return &AttachmentRepository{
+		db: db,
+	}
+}
+
+const attachmentColumns = `a.id, a.item_id, a.user_id, a.name, a.size, a.content_type, a.checksum,
+	a.storage_key, a.created_at`
+
+func (r *AttachmentRepository) Create(userId int, todoItemId int, attachment domain.Attachment) (int, error) {
+	var id int
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err = lockWritableItem(tx, userId, todoItemId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, name, size, content_type, checksum, storage_key)
+	VALUES ($2, $1, $3, $4, $5, $6, $7) RETURNING id`, attachmentsTable)
+	err = tx.QueryRow(query, userId, todoItemId, attachment.Name, attachment.Size, attachment.ContentType,
+		attachment.Checksum, attachment.StorageKey).Scan(&id)
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *AttachmentRepository) GetAll(todoItemId int) ([]domain.Attachment, error) {
+	attachments := make([]domain.Attachment, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a WHERE a.item_id = $1 ORDER BY a.created_at, a.id`,
+		attachmentColumns, attachmentsTable)
+	if err := r.db.Select(&attachments, query, todoItemId); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetById(userId int, attachmentId int) (domain.Attachment, error) {
+	var attachment domain.Attachment
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ti ON ti.id = a.item_id INNER JOIN %s li
+	ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id WHERE a.id = $2 AND ti.deleted_at IS NULL
+	AND tl.deleted_at IS NULL AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id
+	AND ul.user_id = $1)`, attachmentColumns, attachmentsTable, todoItemsTable, listsItemsTable,
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&attachment, query, userId, attachmentId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return attachment, domain.ErrAttachmentNotFound
+		}
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) Delete(attachmentId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	// Attachments of items of archived lists are read-only.
+	query := fmt.Sprintf(`SELECT tl.archived FROM %s tl INNER JOIN %s li ON li.list_id = tl.id
+	INNER JOIN %s a ON a.item_id = li.item_id WHERE a.id = $1 FOR SHARE OF tl`,
+		todoListsTable, listsItemsTable, attachmentsTable)
+	if err = lockUnarchivedList(tx, domain.ErrAttachmentNotFound, query, attachmentId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	query = fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING id`, attachmentsTable)
+	var id int
+	if err = tx.QueryRow(query, attachmentId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetOrphanedBlobs returns storage keys of blobs whose attachments were
+// deleted, directly or together with their items.
+func (r *AttachmentRepository) GetOrphanedBlobs(limit int) ([]string, error) {
+	keys := make([]string, 0)
+
+	query := fmt.Sprintf(`SELECT storage_key FROM %s ORDER BY created_at LIMIT $1`, orphanedBlobsTable)
+	if err := r.db.Select(&keys, query, limit); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *AttachmentRepository) DeleteOrphanedBlob(storageKey string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE storage_key = $1`, orphanedBlobsTable)
+	if _, err := r.db.Exec(query, storageKey); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentRepository struct {
+	db *sqlx.DB
+}
+
+func NewCommentRepository(db *sqlx.DB) *CommentRepository {
+	return &CommentRepository{
+		db: db,
+	}
+}
+
+const commentColumns = `ic.id, ic.item_id, ic.body, ic.created_at, ic.updated_at,
+	u.id AS "author.id", u.name AS "author.name", u.username AS "author.username"`
+
+func (r *CommentRepository) Create(userId int, todoItemId int, body string) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, body) SELECT ti.id, $1, $3 FROM %s ti
+	INNER JOIN %s li ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id INNER JOIN %s ul
+	ON ul.list_id = li.list_id WHERE ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NULL
+	AND tl.deleted_at IS NULL LIMIT 1 RETURNING id`, itemCommentsTable, todoItemsTable, listsItemsTable,
+		todoListsTable, usersListsTable)
+	err := r.db.QueryRow(query, userId, todoItemId, body).Scan(&id)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrItemNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *CommentRepository) GetAll(todoItemId int, limit int, offset int) ([]domain.Comment, int, error) {
+	comments := make([]domain.Comment, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ic INNER JOIN %s u ON u.id = ic.user_id WHERE ic.item_id = $1
+	ORDER BY ic.created_at DESC, ic.id DESC LIMIT $2 OFFSET $3`, commentColumns, itemCommentsTable, usersTable)
+	err := r.db.Select(&comments, query, todoItemId, limit, offset)
+	if err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	var total int
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE item_id = $1`, itemCommentsTable)
+	if err = r.db.Get(&total, query, todoItemId); err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *CommentRepository) GetById(userId int, commentId int) (domain.Comment, error) {
+	var comment domain.Comment
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ic INNER JOIN %s u ON u.id = ic.user_id INNER JOIN %s ti
+	ON ti.id = ic.item_id INNER JOIN %s li ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id
+	WHERE ic.id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL AND EXISTS
+	(SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = $1)`, commentColumns,
+		itemCommentsTable, usersTable, todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err := r.db.Get(&comment, query, userId, commentId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return comment, domain.ErrCommentNotFound
+		}
+		return comment, err
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) Update(commentId int, body string) error {
+	query := fmt.Sprintf(`UPDATE %s SET body = $2, updated_at = now() WHERE id = $1 RETURNING id`,
+		itemCommentsTable)
+
+	var id int
+	if err := r.db.QueryRow(query, commentId, body).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrCommentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentRepository) Delete(commentId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING id`, itemCommentsTable)
+
+	var id int
+	if err := r.db.QueryRow(query, commentId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrCommentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const eventsChannel = "events:activity"
+
+type EventRepository struct {
+	rdb *redis.Client
+}
+
+func NewEventRepository(rdb *redis.Client) *EventRepository {
+	return &EventRepository{
+		rdb: rdb,
+	}
+}
+
+func (r *EventRepository) Publish(ctx context.Context, event domain.ActivityEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err = r.rdb.Publish(ctx, eventsChannel, payload).Err(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *EventRepository) Subscribe(ctx context.Context) (<-chan domain.ActivityEvent, error) {
+	pubsub := r.rdb.Subscribe(ctx, eventsChannel)
+	// Receive waits for the subscription to be confirmed, events published
+	// after Subscribe returns are not missed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		logrus.Error(err)
+		pubsub.Close()
+		return nil, err
+	}
+
+	events := make(chan domain.ActivityEvent)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event domain.ActivityEvent
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					logrus.Error(err)
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
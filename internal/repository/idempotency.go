@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type IdempotencyRepository struct {
+	rdb *redis.Client
+}
+
+func NewIdempotencyRepository(rdb *redis.Client) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		rdb: rdb,
+	}
+}
+
+func (r *IdempotencyRepository) getKey(userId int, key string) string {
+	return fmt.Sprintf("idempotency:%d:%s", userId, key)
+}
+
+// Reserve stores a pending record for the key unless the key is already
+// taken. It reports whether the key was reserved and otherwise returns the
+// record stored under it.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, userId int, key string,
+	fingerprint string, ttl time.Duration) (domain.IdempotentResponse, bool, error) {
+	redisKey := r.getKey(userId, key)
+
+	pending, err := json.Marshal(domain.IdempotentResponse{Fingerprint: fingerprint})
+	if err != nil {
+		logrus.Error(err)
+		return domain.IdempotentResponse{}, false, ErrInternal
+	}
+
+	for {
+		reserved, err := r.rdb.SetNX(ctx, redisKey, pending, ttl).Result()
+		if err != nil {
+			logrus.Error(err)
+			return domain.IdempotentResponse{}, false, ErrInternal
+		}
+		if reserved {
+			return domain.IdempotentResponse{Fingerprint: fingerprint}, true, nil
+		}
+
+		stored, err := r.rdb.Get(ctx, redisKey).Bytes()
+		if errors.Is(err, redis.Nil) {
+			// The record expired between the two calls, try to take the key again.
+			continue
+		}
+		if err != nil {
+			logrus.Error(err)
+			return domain.IdempotentResponse{}, false, ErrInternal
+		}
+
+		var response domain.IdempotentResponse
+		if err = json.Unmarshal(stored, &response); err != nil {
+			logrus.Error(err)
+			return domain.IdempotentResponse{}, false, ErrInternal
+		}
+		return response, false, nil
+	}
+}
+
+func (r *IdempotencyRepository) Save(ctx context.Context, userId int, key string,
+	response domain.IdempotentResponse, ttl time.Duration) error {
+	value, err := json.Marshal(response)
+	if err != nil {
+		logrus.Error(err)
+		return ErrInternal
+	}
+
+	if err = r.rdb.Set(ctx, r.getKey(userId, key), value, ttl).Err(); err != nil {
+		logrus.Error(err)
+		return ErrInternal
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, userId int, key string) error {
+	if err := r.rdb.Del(ctx, r.getKey(userId, key)).Err(); err != nil {
+		logrus.Error(err)
+		return ErrInternal
+	}
+	return nil
+}
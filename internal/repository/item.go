@@ -8,6 +8,7 @@ import (
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,22 +22,30 @@ func NewTodoItemRepository(db *sqlx.DB) *TodoItemRepository {
 	}
 }
 
-func (r *TodoItemRepository) Create(todoListId int, todoItem domain.TodoItem) (int, error) {
-	tx, err := r.db.Begin()
+func (r *TodoItemRepository) Create(userId int, todoListId int, todoItem domain.TodoItem) (int, error) {
+	tx, err := r.db.Beginx()
 	if err != nil {
 		logrus.Error(err)
 		return 0, err
 	}
 
-	query := fmt.Sprintf(`INSERT INTO %s (title, description, done) VALUES 
-	($1, $2, $3) RETURNING id`, todoItemsTable)
-	row := tx.QueryRow(query, todoItem.Title, todoItem.Description, todoItem.Done)
-	if err = row.Scan(&todoItem.Id); err != nil {
+	if err = checkListWritable(tx, userId, todoListId); err != nil {
 		logrus.Error(err)
 		tx.Rollback()
 		return 0, err
 	}
 
+	query := fmt.Sprintf(`INSERT INTO %s (title, description, done, status_id, client_id) VALUES 
+	($1, $2, COALESCE((SELECT is_done FROM %s WHERE id = $4 AND list_id = $5), $3), $4, $6) RETURNING id`,
+		todoItemsTable, listStatusesTable)
+	row := tx.QueryRow(query, todoItem.Title, todoItem.Description, todoItem.Done, todoItem.StatusId, todoListId,
+		todoItem.ClientId)
+	if err = row.Scan(&todoItem.Id); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, clientIdError(err)
+	}
+
 	query = fmt.Sprintf(`INSERT INTO %s (list_id, item_id) VALUES
 	($1, $2)`, listsItemsTable)
 	_, err = tx.Exec(query, todoListId, todoItem.Id)
@@ -46,6 +55,25 @@ func (r *TodoItemRepository) Create(todoListId int, todoItem domain.TodoItem) (i
 		return 0, err
 	}
 
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemCreated, todoListId, todoItem.Id); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = writeItemActivity(tx, userId, todoListId, todoItem.Id, domain.ActivityItemCreated, nil)
+	if err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
 	tx.Commit()
 	return todoItem.Id, nil
 }
@@ -54,7 +82,7 @@ func (r *TodoItemRepository) GetAll(todoListId int) ([]domain.TodoItem, error) {
 	var todoItems []domain.TodoItem
 
 	query := fmt.Sprintf(`SELECT ti.* FROM %s ti INNER JOIN %s li ON li.item_id = ti.id 
-	WHERE li.list_id = $1`, todoItemsTable, listsItemsTable)
+	WHERE li.list_id = $1 AND ti.deleted_at IS NULL`, todoItemsTable, listsItemsTable)
 	err := r.db.Select(&todoItems, query, todoListId)
 	if err != nil {
 		logrus.Error(err)
@@ -64,16 +92,50 @@ func (r *TodoItemRepository) GetAll(todoListId int) ([]domain.TodoItem, error) {
 	return todoItems, nil
 }
 
+// listTodoItem is an item with the list it belongs to.
+type listTodoItem struct {
+	domain.TodoItem
+	ListId int `db:"list_id"`
+}
+
+// GetAllByLists returns the items of the lists the user has access to, by
+// list. Every requested list has an entry, empty if it has no items.
+func (r *TodoItemRepository) GetAllByLists(userId int, todoListIds []int) (map[int][]domain.TodoItem, error) {
+	var rows []listTodoItem
+
+	query := fmt.Sprintf(`SELECT ti.*, li.list_id FROM %s ti INNER JOIN %s li ON li.item_id = ti.id INNER JOIN
+	%s tl ON tl.id = li.list_id WHERE li.list_id = ANY($2) AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL
+	AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = $1) ORDER BY ti.id`,
+		todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err := r.db.Select(&rows, query, userId, pq.Array(todoListIds))
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	todoItems := make(map[int][]domain.TodoItem, len(todoListIds))
+	for _, todoListId := range todoListIds {
+		todoItems[todoListId] = []domain.TodoItem{}
+	}
+	for _, row := range rows {
+		todoItems[row.ListId] = append(todoItems[row.ListId], row.TodoItem)
+	}
+
+	return todoItems, nil
+}
+
 func (r *TodoItemRepository) GetById(userId int, todoItemId int) (domain.TodoItem, error) {
 	var todoItem domain.TodoItem
 
 	query := fmt.Sprintf(`SELECT ti.* FROM %s ti INNER JOIN %s li ON li.item_id = ti.id INNER JOIN
-	%s ul ON ul.list_id = li.list_id WHERE ul.user_id = $1`, todoItemsTable, listsItemsTable, usersListsTable)
-	err := r.db.Get(&todoItem, query, userId)
+	%s tl ON tl.id = li.list_id INNER JOIN %s ul ON ul.list_id = li.list_id WHERE ul.user_id = $1
+	AND ti.id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL`, todoItemsTable, listsItemsTable,
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&todoItem, query, userId, todoItemId)
 	if err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return todoItem, errors.New("not found")
+			return todoItem, domain.ErrItemNotFound
 		}
 		return todoItem, err
 	}
@@ -81,25 +143,104 @@ func (r *TodoItemRepository) GetById(userId int, todoItemId int) (domain.TodoIte
 	return todoItem, nil
 }
 
-func (r *TodoItemRepository) Delete(userId int, todoItemId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s ul WHERE li.item_id = ti.id AND
-	li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2 RETURNING ti.id`,
-		todoItemsTable, listsItemsTable, usersListsTable)
-	var id int
-	fmt.Println(query)
-	row := r.db.QueryRow(query, userId, todoItemId)
-	if err := row.Scan(&id); err != nil {
+// lockedTodoItem is an item read for a write, with the list it is in.
+type lockedTodoItem struct {
+	domain.TodoItem
+	ListId       int  `db:"list_id"`
+	ListArchived bool `db:"list_archived"`
+}
+
+// lockItem reads the item for a write, writes of others wait until the
+// transaction ends. Its list is locked too, so it isn't archived meanwhile.
+func lockItem(tx *sqlx.Tx, userId int, todoItemId int) (lockedTodoItem, error) {
+	var todoItem lockedTodoItem
+
+	query := fmt.Sprintf(`SELECT ti.*, li.list_id, tl.archived AS list_archived FROM %s ti INNER JOIN %s li
+	ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id INNER JOIN %s ul ON ul.list_id = li.list_id
+	WHERE ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL
+	FOR UPDATE OF ti FOR SHARE OF tl`, todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err := tx.Get(&todoItem, query, userId, todoItemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoItem, domain.ErrItemNotFound
+	}
+	return todoItem, err
+}
+
+// lockWritableItem reads the item for a write like lockItem, items of
+// archived lists are read-only.
+func lockWritableItem(tx *sqlx.Tx, userId int, todoItemId int) (lockedTodoItem, error) {
+	todoItem, err := lockItem(tx, userId, todoItemId)
+	if err == nil && todoItem.ListArchived {
+		return todoItem, domain.ErrListArchived
+	}
+	return todoItem, err
+}
+
+// writeItemUpdate records the changes an update made to the item, if any.
+func writeItemUpdate(tx sqlx.Ext, userId int, todoListId int, before domain.TodoItem, after domain.TodoItem) error {
+	changes := domain.ItemChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	return writeItemActivity(tx, userId, todoListId, after.Id, domain.ItemUpdateAction(changes), changes)
+}
+
+// missError tells why a write conditional on the item version matched no
+// rows.
+func (r *TodoItemRepository) missError(userId int, todoItemId int, version *int) error {
+	if version != nil {
+		if _, err := r.GetById(userId, todoItemId); err == nil {
+			return ErrVersionMismatch
+		}
+	}
+	return domain.ErrItemNotFound
+}
+
+func (r *TodoItemRepository) Delete(userId int, todoItemId int, version *int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = lockWritableItem(tx, userId, todoItemId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s ti SET deleted_at = now() FROM %s li, %s tl, %s ul WHERE
+	li.item_id = ti.id AND tl.id = li.list_id AND ul.list_id = li.list_id AND ul.user_id = $1 AND ti.id = $2
+	AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL AND ($3::integer IS NULL OR ti.version = $3)
+	RETURNING ti.id, li.list_id`, todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	var id, todoListId int
+	row := tx.QueryRow(query, userId, todoItemId, version)
+	if err = row.Scan(&id, &todoListId); err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("not found")
+			return r.missError(userId, todoItemId, version)
 		}
 		return err
 	}
 
+	if err = writeItemEvent(tx, domain.ActivityItemDeleted, todoListId, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeItemActivity(tx, userId, todoListId, id, domain.ActivityItemDeleted, nil); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
 	return nil
 }
 
-func (r *TodoItemRepository) Update(userId int, todoItemId int, updateTodoItem domain.UpdateTodoItem) (domain.TodoItem, error) {
+func todoItemSetQuery(updateTodoItem domain.UpdateTodoItem) (string, []interface{}) {
 	var values = make([]interface{}, 0)
 	var names = make([]string, 0)
 	var argId = 1
@@ -122,22 +263,343 @@ func (r *TodoItemRepository) Update(userId int, todoItemId int, updateTodoItem d
 		appendArg("done", *updateTodoItem.Done)
 	}
 
-	setQuery := strings.Join(names, ", ")
-	values = append(values, userId, todoItemId)
-
-	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li, %s ul WHERE ti.id = li.item_id AND
-	ul.list_id = li.list_id AND ul.user_id = $%d AND ti.id = $%d RETURNING ti.*`, todoItemsTable, setQuery,
-		listsItemsTable, usersListsTable, argId, argId+1)
+	return strings.Join(names, ", "), values
+}
 
+func (r *TodoItemRepository) Replace(userId int, todoItemId int, replaceTodoItem domain.ReplaceTodoItem) (domain.TodoItem, error) {
 	var todoItem domain.TodoItem
-	err := r.db.Get(&todoItem, query, values...)
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockWritableItem(tx, userId, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	var todoListId int
+	query := fmt.Sprintf(`UPDATE %s ti SET title = $3, description = $4, done = $5 FROM %s li, %s tl, %s ul
+	WHERE ti.id = li.item_id AND tl.id = li.list_id AND ul.list_id = li.list_id AND ul.user_id = $1
+	AND ti.id = $2 AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL AND ($6::integer IS NULL OR ti.version = $6)
+	RETURNING li.list_id`, todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err = tx.QueryRow(query, userId, todoItemId, replaceTodoItem.Title, replaceTodoItem.Description,
+		replaceTodoItem.Done, replaceTodoItem.Version).Scan(&todoListId)
 	if err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return todoItem, errors.New("not found")
+			return todoItem, r.missError(userId, todoItemId, replaceTodoItem.Version)
 		}
 		return todoItem, err
 	}
 
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE id = $1`, todoItemsTable)
+	if err = tx.Get(&todoItem, query, todoItemId); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemUpdated, todoListId, todoItem.Id); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = writeItemUpdate(tx, userId, todoListId, before.TodoItem, todoItem); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
 	return todoItem, nil
 }
+
+func (r *TodoItemRepository) Move(userId int, todoItemId int, todoListId int) (domain.TodoItem, error) {
+	var todoItem domain.TodoItem
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	defer tx.Rollback()
+
+	if err = checkListWritable(tx, userId, todoListId); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	// The update below only locks the row of lists_items, the item's own row
+	// is locked for its event.
+	before, err := lockWritableItem(tx, userId, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	sourceListId := before.ListId
+
+	query := fmt.Sprintf(`UPDATE %s SET list_id = $2 WHERE item_id = $1`, listsItemsTable)
+	if _, err = tx.Exec(query, before.Id, todoListId); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	query = fmt.Sprintf(`SELECT * FROM %s WHERE id = $1`, todoItemsTable)
+	if err = tx.Get(&todoItem, query, before.Id); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemMoved, todoListId, todoItem.Id); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if sourceListId != todoListId {
+		// The move is recorded on the source list, the one that lost the item.
+		err = writeItemActivity(tx, userId, sourceListId, todoItem.Id, domain.ActivityItemMoved, domain.Changes{
+			"listId": {Old: sourceListId, New: todoListId},
+		})
+		if err != nil {
+			logrus.Error(err)
+			return todoItem, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	return todoItem, nil
+}
+
+func (r *TodoItemRepository) Copy(userId int, todoItemId int, todoListId int) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err = checkListWritable(tx, userId, todoListId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (title, description, done, status_id) SELECT ti.title, ti.description,
+	ti.done, ti.status_id FROM %s ti INNER JOIN %s li ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id
+	INNER JOIN %s ul ON ul.list_id = li.list_id WHERE ul.user_id = $1 AND ti.id = $2
+	AND ti.deleted_at IS NULL AND tl.deleted_at IS NULL RETURNING id`, todoItemsTable, todoItemsTable,
+		listsItemsTable, todoListsTable, usersListsTable)
+	if err = tx.QueryRow(query, userId, todoItemId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrItemNotFound
+		}
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (list_id, item_id) VALUES ($1, $2)`, listsItemsTable)
+	if _, err = tx.Exec(query, todoListId, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemCreated, todoListId, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeItemActivity(tx, userId, todoListId, id, domain.ActivityItemCreated, nil); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *TodoItemRepository) Bulk(userId int, todoListId int,
+	operations []domain.BulkItemOperation) ([]domain.BulkItemResult, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = checkListWritable(tx, userId, todoListId); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	before, err := lockListItems(tx, todoListId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	results := make([]domain.BulkItemResult, 0, len(operations))
+	for _, operation := range operations {
+		var id int
+		switch operation.Op {
+		case domain.BulkOperationCreate:
+			id, err = r.bulkCreate(tx, todoListId, operation.TodoItem())
+		case domain.BulkOperationUpdate:
+			id, err = r.bulkUpdate(tx, todoListId, operation.Id, operation.UpdateTodoItem())
+		case domain.BulkOperationComplete:
+			done := true
+			id, err = r.bulkUpdate(tx, todoListId, operation.Id, domain.UpdateTodoItem{Done: &done})
+		case domain.BulkOperationDelete:
+			id, err = r.bulkDelete(tx, todoListId, operation.Id)
+		default:
+			err = fmt.Errorf("unknown operation %q", operation.Op)
+		}
+		if err != nil {
+			logrus.Error(err)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, domain.ErrItemNotFound
+			}
+			return nil, err
+		}
+		results = append(results, domain.BulkItemResult{Op: operation.Op, Id: id})
+
+		if err = writeItemEvent(tx, bulkEvents[operation.Op], todoListId, id); err != nil {
+			logrus.Error(err)
+			return nil, err
+		}
+	}
+
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	if err = writeBulkActivities(tx, userId, todoListId, results, before); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// lockListItems reads the items of the list for a write, by id.
+func lockListItems(tx *sqlx.Tx, todoListId int) (map[int]domain.TodoItem, error) {
+	var todoItems []domain.TodoItem
+
+	query := fmt.Sprintf(`SELECT ti.* FROM %s ti INNER JOIN %s li ON li.item_id = ti.id
+	WHERE li.list_id = $1 AND ti.deleted_at IS NULL FOR UPDATE OF ti`, todoItemsTable, listsItemsTable)
+	if err := tx.Select(&todoItems, query, todoListId); err != nil {
+		return nil, err
+	}
+
+	byId := make(map[int]domain.TodoItem, len(todoItems))
+	for _, todoItem := range todoItems {
+		byId[todoItem.Id] = todoItem
+	}
+	return byId, nil
+}
+
+// writeBulkActivities records the operations of a batch once it is applied,
+// updates are compared with the items as they were before the batch.
+func writeBulkActivities(tx *sqlx.Tx, userId int, todoListId int, results []domain.BulkItemResult,
+	before map[int]domain.TodoItem) error {
+	after, err := lockListItems(tx, todoListId)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		switch result.Op {
+		case domain.BulkOperationCreate:
+			err = writeItemActivity(tx, userId, todoListId, result.Id, domain.ActivityItemCreated, nil)
+		case domain.BulkOperationDelete:
+			err = writeItemActivity(tx, userId, todoListId, result.Id, domain.ActivityItemDeleted, nil)
+		case domain.BulkOperationUpdate, domain.BulkOperationComplete:
+			todoItemBefore, okBefore := before[result.Id]
+			todoItemAfter, okAfter := after[result.Id]
+			if okBefore && okAfter {
+				err = writeItemUpdate(tx, userId, todoListId, todoItemBefore, todoItemAfter)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkEvents maps bulk operations to the events they write to the outbox.
+var bulkEvents = map[string]string{
+	domain.BulkOperationCreate:   domain.ActivityItemCreated,
+	domain.BulkOperationUpdate:   domain.ActivityItemUpdated,
+	domain.BulkOperationComplete: domain.ActivityItemUpdated,
+	domain.BulkOperationDelete:   domain.ActivityItemDeleted,
+}
+
+func (r *TodoItemRepository) bulkCreate(tx *sqlx.Tx, todoListId int, todoItem domain.TodoItem) (int, error) {
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (title, description, done) VALUES ($1, $2, $3) RETURNING id`,
+		todoItemsTable)
+	if err := tx.QueryRow(query, todoItem.Title, todoItem.Description, todoItem.Done).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (list_id, item_id) VALUES ($1, $2)`, listsItemsTable)
+	if _, err := tx.Exec(query, todoListId, id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *TodoItemRepository) bulkUpdate(tx *sqlx.Tx, todoListId int, todoItemId int,
+	updateTodoItem domain.UpdateTodoItem) (int, error) {
+	setQuery, values := todoItemSetQuery(updateTodoItem)
+	argId := len(values) + 1
+	values = append(values, todoListId, todoItemId)
+
+	var id int
+	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li WHERE ti.id = li.item_id AND li.list_id = $%d
+	AND ti.id = $%d AND ti.deleted_at IS NULL RETURNING ti.id`, todoItemsTable, setQuery, listsItemsTable,
+		argId, argId+1)
+	err := tx.QueryRow(query, values...).Scan(&id)
+	return id, err
+}
+
+func (r *TodoItemRepository) bulkDelete(tx *sqlx.Tx, todoListId int, todoItemId int) (int, error) {
+	var id int
+	query := fmt.Sprintf(`UPDATE %s ti SET deleted_at = now() FROM %s li WHERE li.item_id = ti.id
+	AND li.list_id = $1 AND ti.id = $2 AND ti.deleted_at IS NULL RETURNING ti.id`, todoItemsTable,
+		listsItemsTable)
+	err := tx.QueryRow(query, todoListId, todoItemId).Scan(&id)
+	return id, err
+}
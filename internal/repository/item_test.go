@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoItemRepository_Bulk(t *testing.T) {
+	title := "new"
+	operations := []domain.BulkItemOperation{
+		{Op: domain.BulkOperationCreate, Title: &title},
+		{Op: domain.BulkOperationComplete, Id: 3},
+		{Op: domain.BulkOperationDelete, Id: 4},
+	}
+	itemColumns := []string{"id", "title", "description", "done", "status_id", "version", "deleted_at", "client_id"}
+
+	testTable := []struct {
+		name            string
+		mockBehavior    func(mock sqlmock.Sqlmock)
+		expectedResults []domain.BulkItemResult
+		expectedError   error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "open", nil, false, 1, 1, nil, nil).
+					AddRow(4, "stale", nil, false, 1, 1, nil, nil))
+				mock.ExpectQuery("INSERT INTO todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO lists_items").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE todo_items").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "open", nil, true, 2, 2, nil, nil).
+					AddRow(7, "new", nil, false, 1, 1, nil, nil))
+				mock.ExpectQuery("INSERT INTO activities").WithArgs(1, 7, 2, domain.ActivityItemCreated,
+					sqlmock.AnyArg(), nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("INSERT INTO activities").WithArgs(1, 3, 2, domain.ActivityItemCompleted,
+					sqlmock.AnyArg(), nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("INSERT INTO activities").WithArgs(1, 4, 2, domain.ActivityItemDeleted,
+					sqlmock.AnyArg(), nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(13))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectedResults: []domain.BulkItemResult{
+				{Op: domain.BulkOperationCreate, Id: 7},
+				{Op: domain.BulkOperationComplete, Id: 3},
+				{Op: domain.BulkOperationDelete, Id: 4},
+			},
+		},
+		{
+			// The created item and the completed one are rolled back with
+			// the failed delete.
+			name: "failed operation rolls back the batch",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "open", nil, false, 1, 1, nil, nil))
+				mock.ExpectQuery("INSERT INTO todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO lists_items").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE todo_items").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			expectedError: domain.ErrItemNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			testCase.mockBehavior(mock)
+			repo := NewTodoItemRepository(sqlx.NewDb(db, "postgres"))
+
+			results, err := repo.Bulk(2, 1, operations)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedResults, results)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemRepository_Move(t *testing.T) {
+	itemColumns := []string{"id", "title", "description", "done", "status_id", "version", "deleted_at", "client_id"}
+	lockedColumns := append(itemColumns, "list_id", "list_archived")
+
+	testTable := []struct {
+		name          string
+		mockBehavior  func(mock sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			// The item's row is locked before the move and its event.
+			name: "ok",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows(lockedColumns).AddRow(3, "item", nil, false, 1, 1, nil, nil, 1, false))
+				mock.ExpectExec("UPDATE lists_items").WithArgs(3, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE todo_items").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM todo_items").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "item", nil, false, 4, 1, nil, nil))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO activities").WithArgs(1, 3, 2, domain.ActivityItemMoved,
+					sqlmock.AnyArg(), nil).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "item not found",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(false))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows(lockedColumns))
+				mock.ExpectRollback()
+			},
+			expectedError: domain.ErrItemNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			testCase.mockBehavior(mock)
+			repo := NewTodoItemRepository(sqlx.NewDb(db, "postgres"))
+
+			todoItem, err := repo.Move(2, 3, 5)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			if testCase.expectedError == nil {
+				assert.Equal(t, 3, todoItem.Id)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemRepository_archivedList(t *testing.T) {
+	lockedColumns := []string{"id", "title", "description", "done", "status_id", "version", "deleted_at", "client_id",
+		"list_id", "list_archived"}
+	version := 1
+
+	// The list is read under a lock in the transaction of the write, so it
+	// can't be archived between the check and the write.
+	testTable := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		call         func(repo *TodoItemRepository) error
+	}{
+		{
+			name: "create",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+			},
+			call: func(repo *TodoItemRepository) error {
+				_, err := repo.Create(2, 1, domain.TodoItem{Title: "item"})
+				return err
+			},
+		},
+		{
+			name: "replace",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti FOR SHARE OF tl").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows(lockedColumns).AddRow(3, "item", nil, false, nil, 1, nil, nil, 1, true))
+			},
+			call: func(repo *TodoItemRepository) error {
+				_, err := repo.Replace(2, 3, domain.ReplaceTodoItem{Title: "new", Version: &version})
+				return err
+			},
+		},
+		{
+			name: "delete",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti FOR SHARE OF tl").WithArgs(2, 3).
+					WillReturnRows(sqlmock.NewRows(lockedColumns).AddRow(3, "item", nil, false, nil, 1, nil, nil, 1, true))
+			},
+			call: func(repo *TodoItemRepository) error {
+				return repo.Delete(2, 3, nil)
+			},
+		},
+		{
+			name: "move to an archived list",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+			},
+			call: func(repo *TodoItemRepository) error {
+				_, err := repo.Move(2, 3, 5)
+				return err
+			},
+		},
+		{
+			name: "bulk",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT tl.archived (.+) FOR SHARE OF tl").WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+			},
+			call: func(repo *TodoItemRepository) error {
+				_, err := repo.Bulk(2, 1, []domain.BulkItemOperation{{Op: domain.BulkOperationComplete, Id: 3}})
+				return err
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			testCase.mockBehavior(mock)
+			mock.ExpectRollback()
+			repo := NewTodoItemRepository(sqlx.NewDb(db, "postgres"))
+
+			err = testCase.call(repo)
+
+			assert.ErrorIs(t, err, domain.ErrListArchived)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,13 +4,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	ErrVersionMismatch = errors.New("version mismatch")
+)
+
 type TodoListRepository struct {
 	db *sqlx.DB
 }
@@ -22,20 +25,20 @@ func NewTodoListRepository(db *sqlx.DB) *TodoListRepository {
 }
 
 func (r *TodoListRepository) Create(userId int, todoList domain.TodoList) (int, error) {
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
 		logrus.Error(err)
 		return 0, err
 	}
 
 	var id int
-	query := fmt.Sprintf(`INSERT INTO %s (title, description)
-	 VALUES ($1, $2) RETURNING id`, todoListsTable)
-	row := tx.QueryRow(query, todoList.Title, todoList.Description)
+	query := fmt.Sprintf(`INSERT INTO %s (title, description, client_id)
+	 VALUES ($1, $2, $3) RETURNING id`, todoListsTable)
+	row := tx.QueryRow(query, todoList.Title, todoList.Description, todoList.ClientId)
 	if err := row.Scan(&id); err != nil {
 		logrus.Error(err)
 		tx.Rollback()
-		return 0, err
+		return 0, clientIdError(err)
 	}
 
 	query = fmt.Sprintf(`INSERT INTO %s (user_id, list_id) VALUES ($1, $2)`, usersListsTable)
@@ -46,16 +49,35 @@ func (r *TodoListRepository) Create(userId int, todoList domain.TodoList) (int,
 		return 0, err
 	}
 
+	if err = createDefaultStatuses(tx, id); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = writeListEvent(tx, domain.ActivityListCreated, id); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = writeListActivity(tx, userId, id, domain.ActivityListCreated, nil); err != nil {
+		logrus.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
 	tx.Commit()
 	return id, nil
 }
 
-func (r *TodoListRepository) GetAll(userId int) ([]domain.TodoList, error) {
+func (r *TodoListRepository) GetAll(userId int, archived bool) ([]domain.TodoList, error) {
 	var todoLists []domain.TodoList
 
 	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN
-	 %s ul ON ul.list_id = tl.id WHERE ul.user_id = $1`, todoListsTable, usersListsTable)
-	err := r.db.Select(&todoLists, query, userId)
+	 %s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND tl.archived = $2 AND tl.deleted_at IS NULL
+	 ORDER BY tl.pinned DESC, tl.id`, todoListsTable, usersListsTable)
+	err := r.db.Select(&todoLists, query, userId, archived)
 	if err != nil {
 		logrus.Error(err)
 		return nil, err
@@ -68,12 +90,13 @@ func (r *TodoListRepository) GetById(userId int, todoListId int) (domain.TodoLis
 	var todoList domain.TodoList
 
 	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN
-	%s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND tl.id = $2`, todoListsTable, usersListsTable)
+	%s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NULL`,
+		todoListsTable, usersListsTable)
 	err := r.db.Get(&todoList, query, userId, todoListId)
 	if err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return todoList, errors.New("not found")
+			return todoList, domain.ErrListNotFound
 		}
 		return todoList, err
 	}
@@ -81,56 +104,362 @@ func (r *TodoListRepository) GetById(userId int, todoListId int) (domain.TodoLis
 	return todoList, nil
 }
 
-func (r *TodoListRepository) Delete(userId int, todoListId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s tl USING %s ul WHERE ul.list_id = tl.id AND
-	ul.user_id = $1 AND tl.id = $2 RETURNING tl.id`, todoListsTable, usersListsTable)
-	row := r.db.QueryRow(query, userId, todoListId)
+// lockList reads the list for a write, writes of others wait until the
+// transaction ends.
+func lockList(tx *sqlx.Tx, userId int, todoListId int) (domain.TodoList, error) {
+	var todoList domain.TodoList
+
+	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id
+	WHERE ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NULL FOR UPDATE OF tl`,
+		todoListsTable, usersListsTable)
+	err := tx.Get(&todoList, query, userId, todoListId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoList, domain.ErrListNotFound
+	}
+	return todoList, err
+}
+
+// checkListWritable makes sure the user can add items to the list, that is
+// the list is accessible and not archived. It stays so until the transaction
+// ends.
+func checkListWritable(tx *sqlx.Tx, userId int, todoListId int) error {
+	query := fmt.Sprintf(`SELECT tl.archived FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id
+	WHERE ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NULL FOR SHARE OF tl`,
+		todoListsTable, usersListsTable)
+	return lockUnarchivedList(tx, domain.ErrListNotFound, query, userId, todoListId)
+}
+
+// lockWritableList keeps the list from being archived until the transaction
+// ends like checkListWritable, the caller checks the access to it.
+func lockWritableList(tx *sqlx.Tx, todoListId int) error {
+	query := fmt.Sprintf(`SELECT archived FROM %s WHERE id = $1 AND deleted_at IS NULL FOR SHARE`,
+		todoListsTable)
+	return lockUnarchivedList(tx, domain.ErrListNotFound, query, todoListId)
+}
+
+// lockUnarchivedList keeps the list selected by the query from being
+// archived until the transaction ends, and fails if it already is. The query
+// selects the archived column of the list FOR SHARE, notFound is returned
+// when it selects no row.
+func lockUnarchivedList(tx *sqlx.Tx, notFound error, query string, args ...interface{}) error {
+	var archived bool
+	if err := tx.QueryRow(query, args...).Scan(&archived); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFound
+		}
+		return err
+	}
+	if archived {
+		return domain.ErrListArchived
+	}
+	return nil
+}
+
+// writeListUpdate records the changes an update made to the list, if any.
+func writeListUpdate(tx sqlx.Ext, userId int, before domain.TodoList, after domain.TodoList) error {
+	changes := domain.ListChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	return writeListActivity(tx, userId, after.Id, domain.ActivityListUpdated, changes)
+}
+
+// missError tells why a write on the list matched no rows: archived lists
+// are read-only, and a write conditional on the version fails if it changed.
+func (r *TodoListRepository) missError(userId int, todoListId int, version *int) error {
+	todoList, err := r.GetById(userId, todoListId)
+	if err != nil {
+		return domain.ErrListNotFound
+	}
+	if todoList.Archived {
+		return domain.ErrListArchived
+	}
+	if version != nil {
+		return ErrVersionMismatch
+	}
+	return domain.ErrListNotFound
+}
+
+func (r *TodoListRepository) Delete(userId int, todoListId int, version *int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`UPDATE %s tl SET deleted_at = now() FROM %s ul WHERE ul.list_id = tl.id AND
+	ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NULL AND NOT tl.archived
+	AND ($3::integer IS NULL OR tl.version = $3) RETURNING tl.id`, todoListsTable, usersListsTable)
+	row := tx.QueryRow(query, userId, todoListId, version)
 
 	var id int
-	err := row.Scan(&id)
+	err = row.Scan(&id)
 	if err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("not found")
+			return r.missError(userId, todoListId, version)
 		}
 		return err
 	}
 
+	if err = writeListEvent(tx, domain.ActivityListDeleted, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeListActivity(tx, userId, id, domain.ActivityListDeleted, nil); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
 	return nil
 }
 
-func (r *TodoListRepository) Update(userId int, todoListId int, updateTodoList domain.UpdateTodoList) (domain.TodoList, error) {
-	var valueNames = make([]string, 0)
-	var values = make([]interface{}, 0)
-	var argId = 1
+func (r *TodoListRepository) Replace(userId int, todoListId int, replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error) {
+	query := fmt.Sprintf(`UPDATE %s tl SET title = $3, description = $4 FROM %s ul WHERE ul.list_id = tl.id
+	AND ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NULL AND NOT tl.archived
+	AND ($5::integer IS NULL OR tl.version = $5) RETURNING tl.*`, todoListsTable, usersListsTable)
+
+	var todoList domain.TodoList
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockList(tx, userId, todoListId)
+	if err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
 
-	addValue := func(name string, value interface{}) {
-		valueNames = append(valueNames, fmt.Sprintf("%s = $%d", name, argId))
-		values = append(values, value)
-		argId++
+	err = tx.Get(&todoList, query, userId, todoListId, replaceTodoList.Title, replaceTodoList.Description,
+		replaceTodoList.Version)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoList, r.missError(userId, todoListId, replaceTodoList.Version)
+		}
+		return todoList, err
 	}
 
-	if updateTodoList.Title != nil {
-		addValue("title", *updateTodoList.Title)
+	if err = writeListEvent(tx, domain.ActivityListUpdated, todoList.Id); err != nil {
+		logrus.Error(err)
+		return todoList, err
 	}
-	if updateTodoList.Description != nil {
-		addValue("description", *updateTodoList.Description)
+
+	if err = writeListUpdate(tx, userId, before, todoList); err != nil {
+		logrus.Error(err)
+		return todoList, err
 	}
 
-	setQuery := strings.Join(valueNames, ", ")
-	query := fmt.Sprintf(`UPDATE %s tl SET %s FROM %s ul WHERE ul.list_id = tl.id AND ul.user_id = $%d
-	AND tl.id = $%d RETURNING tl.*`, todoListsTable, setQuery, usersListsTable, argId, argId+1)
-	values = append(values, userId, todoListId)
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+	return todoList, nil
+}
 
+func (r *TodoListRepository) GetByItemId(userId int, todoItemId int) (domain.TodoList, error) {
 	var todoList domain.TodoList
-	err := r.db.Get(&todoList, query, values...)
+
+	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN %s li ON li.list_id = tl.id INNER JOIN
+	%s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND li.item_id = $2 AND tl.deleted_at IS NULL`,
+		todoListsTable, listsItemsTable, usersListsTable)
+	err := r.db.Get(&todoList, query, userId, todoItemId)
 	if err != nil {
 		logrus.Error(err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return todoList, errors.New("not found")
+			return todoList, domain.ErrListNotFound
 		}
 		return todoList, err
 	}
 
-	return todoList, err
+	return todoList, nil
+}
+
+// setFlag sets a flag of the list. Flags other than archived cannot be
+// changed on archived lists.
+func (r *TodoListRepository) setFlag(userId int, todoListId int, name string, value bool) (domain.TodoList, error) {
+	query := fmt.Sprintf(`UPDATE %s tl SET %s = $3 FROM %s ul WHERE ul.list_id = tl.id AND ul.user_id = $1
+	AND tl.id = $2 AND tl.deleted_at IS NULL AND ($4 OR NOT tl.archived) RETURNING tl.*`,
+		todoListsTable, name, usersListsTable)
+
+	var todoList domain.TodoList
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockList(tx, userId, todoListId)
+	if err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+
+	err = tx.Get(&todoList, query, userId, todoListId, value, name == "archived")
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoList, r.missError(userId, todoListId, nil)
+		}
+		return todoList, err
+	}
+
+	if err = writeListEvent(tx, domain.ActivityListUpdated, todoList.Id); err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+
+	if err = writeListUpdate(tx, userId, before, todoList); err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return todoList, err
+	}
+	return todoList, nil
+}
+
+func (r *TodoListRepository) SetArchived(userId int, todoListId int, archived bool) (domain.TodoList, error) {
+	return r.setFlag(userId, todoListId, "archived", archived)
+}
+
+func (r *TodoListRepository) SetPinned(userId int, todoListId int, pinned bool) (domain.TodoList, error) {
+	return r.setFlag(userId, todoListId, "pinned", pinned)
+}
+
+func (r *TodoListRepository) Duplicate(userId int, todoListId int, duplicate domain.DuplicateTodoList) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (title, description) SELECT COALESCE($3, tl.title), tl.description
+	FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND tl.id = $2
+	AND tl.deleted_at IS NULL RETURNING id`, todoListsTable, todoListsTable, usersListsTable)
+	if err = tx.QueryRow(query, userId, todoListId, duplicate.Title).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrListNotFound
+		}
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (user_id, list_id) VALUES ($1, $2)`, usersListsTable)
+	if _, err = tx.Exec(query, userId, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (list_id, name, position, is_done) SELECT $2, name, position, is_done
+	FROM %s WHERE list_id = $1`, listStatusesTable, listStatusesTable)
+	if _, err = tx.Exec(query, todoListId, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`WITH copied AS (
+		INSERT INTO %s (title, description, done, status_id)
+		SELECT ti.title, ti.description, ti.done AND NOT $2, ti.status_id FROM %s ti
+		INNER JOIN %s li ON li.item_id = ti.id WHERE li.list_id = $1 AND ti.deleted_at IS NULL
+		ORDER BY ti.id RETURNING id
+	) INSERT INTO %s (list_id, item_id) SELECT $3, id FROM copied`,
+		todoItemsTable, todoItemsTable, listsItemsTable, listsItemsTable)
+	if _, err = tx.Exec(query, todoListId, duplicate.ResetDone, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = syncItemStatuses(tx, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeListEvent(tx, domain.ActivityListCreated, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeListActivity(tx, userId, id, domain.ActivityListCreated, nil); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *TodoListRepository) CreateWithItems(userId int, todoList domain.TodoList, todoItems []domain.TodoItem) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id`, todoListsTable)
+	if err = tx.QueryRow(query, todoList.Title, todoList.Description).Scan(&id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (user_id, list_id) VALUES ($1, $2)`, usersListsTable)
+	if _, err = tx.Exec(query, userId, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = createDefaultStatuses(tx, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	for _, todoItem := range todoItems {
+		query = fmt.Sprintf(`WITH created AS (
+			INSERT INTO %s (title, description, done) VALUES ($1, $2, $3) RETURNING id
+		) INSERT INTO %s (list_id, item_id) SELECT $4, id FROM created`, todoItemsTable, listsItemsTable)
+		if _, err = tx.Exec(query, todoItem.Title, todoItem.Description, todoItem.Done, id); err != nil {
+			logrus.Error(err)
+			return 0, err
+		}
+	}
+
+	if err = syncItemStatuses(tx, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeListEvent(tx, domain.ActivityListCreated, id); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = writeListActivity(tx, userId, id, domain.ActivityListCreated, nil); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
 }
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// outboxLock is the key of the advisory lock held while the outbox is
+// relayed. A single relay at a time publishes the events in the order they
+// were written.
+const outboxLock = 4807001
+
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// outboxRow scans the payload of an event, which is stored as jsonb.
+type outboxRow struct {
+	domain.OutboxEvent
+	Payload string `db:"payload"`
+}
+
+// writeEvent adds an event to the outbox in the transaction of the change it
+// describes. The transaction must hold the lock on the aggregate's own row,
+// taken by the statement that changed it or by lockList or lockItem, so the
+// events of an aggregate are numbered in the order they are committed. A
+// lock on a row of another table, like lists_items, is not enough.
+func writeEvent(tx sqlx.Execer, aggregateType string, aggregateId int, eventType string,
+	payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (aggregate_type, aggregate_id, event_type, payload)
+	VALUES ($1, $2, $3, $4)`, outboxTable)
+	_, err = tx.Exec(query, aggregateType, aggregateId, eventType, string(data))
+	return err
+}
+
+func writeListEvent(tx sqlx.Execer, eventType string, todoListId int) error {
+	return writeEvent(tx, domain.AggregateList, todoListId, eventType, domain.ListEvent{ListId: todoListId})
+}
+
+func writeItemEvent(tx sqlx.Execer, eventType string, todoListId int, todoItemId int) error {
+	return writeEvent(tx, domain.AggregateItem, todoItemId, eventType,
+		domain.ItemEvent{ListId: todoListId, ItemId: todoItemId})
+}
+
+// Relay passes up to limit events to publish, oldest first, and removes the
+// ones it published. It stops at the first event publish fails on, so the
+// events after it wait for it. Relay returns right away while another
+// instance relays the outbox.
+func (r *OutboxRepository) Relay(limit int, publish func(event domain.OutboxEvent) error) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.Get(&locked, `SELECT pg_try_advisory_xact_lock($1)`, outboxLock); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	var rows []outboxRow
+	query := fmt.Sprintf(`SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at FROM %s
+	ORDER BY id LIMIT $1`, outboxTable)
+	if err = tx.Select(&rows, query, limit); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	var published []int
+	var publishErr error
+	for _, row := range rows {
+		event := row.OutboxEvent
+		event.Payload = json.RawMessage(row.Payload)
+		if publishErr = publish(event); publishErr != nil {
+			break
+		}
+		published = append(published, event.Id)
+	}
+
+	if len(published) > 0 {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE id = ANY($1)`, outboxTable)
+		if _, err = tx.Exec(query, pq.Array(published)); err != nil {
+			logrus.Error(err)
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return len(published), publishErr
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/pkg/eventbus"
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
 )
@@ -14,6 +17,23 @@ const (
 	usersListsTable = "users_lists"
 	todoItemsTable  = "todo_items"
 	listsItemsTable = "lists_items"
+
+	listTemplatesTable = "list_templates"
+	templateItemsTable = "template_items"
+	listStatusesTable  = "list_statuses"
+
+	itemsAssigneesTable = "items_assignees"
+	itemCommentsTable   = "item_comments"
+	attachmentsTable    = "attachments"
+	orphanedBlobsTable  = "orphaned_blobs"
+	activitiesTable     = "activities"
+
+	webhooksTable          = "webhooks"
+	webhookDeliveriesTable = "webhook_deliveries"
+	outboxTable            = "outbox"
+
+	syncChangesTable    = "sync_changes"
+	syncTombstonesTable = "sync_tombstones"
 )
 
 type Authorization interface {
@@ -29,30 +49,180 @@ type Authorization interface {
 
 type TodoList interface {
 	Create(userId int, list domain.TodoList) (int, error)
-	GetAll(userId int) ([]domain.TodoList, error)
+	GetAll(userId int, archived bool) ([]domain.TodoList, error)
 	GetById(userId int, todoListId int) (domain.TodoList, error)
-	Delete(userId int, todoListId int) error
-	Update(userId int, todoListId int, updateTodoList domain.UpdateTodoList) (domain.TodoList, error)
+	GetByItemId(userId int, todoItemId int) (domain.TodoList, error)
+	Delete(userId int, todoListId int, version *int) error
+	Replace(userId int, todoListId int, replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error)
+	SetArchived(userId int, todoListId int, archived bool) (domain.TodoList, error)
+	SetPinned(userId int, todoListId int, pinned bool) (domain.TodoList, error)
+	Duplicate(userId int, todoListId int, duplicate domain.DuplicateTodoList) (int, error)
+	CreateWithItems(userId int, todoList domain.TodoList, todoItems []domain.TodoItem) (int, error)
 }
 
 type TodoItem interface {
-	Create(todoListId int, todoItem domain.TodoItem) (int, error)
+	Create(userId int, todoListId int, todoItem domain.TodoItem) (int, error)
 	GetAll(todoListId int) ([]domain.TodoItem, error)
+	GetAllByLists(userId int, todoListIds []int) (map[int][]domain.TodoItem, error)
 	GetById(userId int, todoItemId int) (domain.TodoItem, error)
-	Delete(userId int, todoItemId int) error
-	Update(userId int, todoItemId int, updateTodoItem domain.UpdateTodoItem) (domain.TodoItem, error)
+	Delete(userId int, todoItemId int, version *int) error
+	Replace(userId int, todoItemId int, replaceTodoItem domain.ReplaceTodoItem) (domain.TodoItem, error)
+	Move(userId int, todoItemId int, todoListId int) (domain.TodoItem, error)
+	Copy(userId int, todoItemId int, todoListId int) (int, error)
+	Bulk(userId int, todoListId int, operations []domain.BulkItemOperation) ([]domain.BulkItemResult, error)
+}
+
+type Trash interface {
+	GetAll(userId int) (domain.Trash, error)
+	RestoreList(userId int, todoListId int) error
+	RestoreItem(userId int, todoItemId int) error
+	DeleteList(userId int, todoListId int) error
+	DeleteItem(userId int, todoItemId int) error
+	Purge(retention time.Duration) (int, error)
+}
+
+type Template interface {
+	CreateFromList(userId int, todoListId int, title *string) (int, error)
+	GetAll(userId int) ([]domain.ListTemplate, error)
+	GetById(userId int, templateId int) (domain.ListTemplate, error)
+	Delete(userId int, templateId int) error
+}
+
+type Status interface {
+	GetAll(todoListId int) ([]domain.Status, error)
+	GetById(userId int, statusId int) (domain.Status, error)
+	Create(todoListId int, status domain.Status) (int, error)
+	Update(statusId int, updateStatus domain.UpdateStatus) (domain.Status, error)
+	Delete(statusId int) error
+	SetItemStatus(userId int, todoItemId int, statusId int) (domain.TodoItem, error)
+}
+
+type Assignee interface {
+	GetAll(todoItemId int) ([]domain.Assignee, error)
+	GetListMembers(todoListId int) ([]domain.Assignee, error)
+	GetAllByItems(userId int, todoItemIds []int) (map[int][]domain.Assignee, error)
+	GetListMembersByLists(userId int, todoListIds []int) (map[int][]domain.Assignee, error)
+	Set(userId int, todoItemId int, userIds []int) error
+	GetAssigned(userId int) ([]domain.AssignedTodoItem, error)
+}
+
+type Comment interface {
+	Create(userId int, todoItemId int, body string) (int, error)
+	GetAll(todoItemId int, limit int, offset int) ([]domain.Comment, int, error)
+	GetById(userId int, commentId int) (domain.Comment, error)
+	Update(commentId int, body string) error
+	Delete(commentId int) error
+}
+
+type Attachment interface {
+	Create(userId int, todoItemId int, attachment domain.Attachment) (int, error)
+	GetAll(todoItemId int) ([]domain.Attachment, error)
+	GetById(userId int, attachmentId int) (domain.Attachment, error)
+	Delete(attachmentId int) error
+	GetOrphanedBlobs(limit int) ([]string, error)
+	DeleteOrphanedBlob(storageKey string) error
+}
+
+// BlobStore keeps attachment contents. Implementations live in pkg/blobstore.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Activity interface {
+	Undo(userId int, activity domain.Activity) (int, error)
+	GetById(userId int, activityId int) (domain.Activity, error)
+	GetWithMembers(activityId int) (domain.Activity, []int, error)
+	GetLastUndoable(userId int, actions []string, since time.Time) (domain.Activity, error)
+	GetByList(todoListId int, limit int, offset int) ([]domain.Activity, int, error)
+	GetByItem(todoItemId int, limit int, offset int) ([]domain.Activity, int, error)
+	GetSince(userId int, activityId int, limit int) ([]domain.Activity, error)
+}
+
+// Events carries activity events between the instances of the app.
+// Subscribe returns the events published from then on, the channel is closed
+// when ctx is done or the subscription is lost.
+type Events interface {
+	Publish(ctx context.Context, event domain.ActivityEvent) error
+	Subscribe(ctx context.Context) (<-chan domain.ActivityEvent, error)
+}
+
+type Webhook interface {
+	Create(userId int, webhook domain.Webhook) (int, error)
+	GetAll(todoListId int) ([]domain.Webhook, error)
+	GetById(userId int, webhookId int) (domain.Webhook, error)
+	Update(webhookId int, input domain.UpdateWebhook) (domain.Webhook, error)
+	Delete(webhookId int) error
+	GetDeliveries(webhookId int, limit int, offset int) ([]domain.WebhookDelivery, int, error)
+	GetDeliveryById(userId int, deliveryId int) (domain.WebhookDelivery, error)
+	Redeliver(deliveryId int) (domain.WebhookDelivery, error)
+	ClaimDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error)
+	GetActivity(activityId int) (domain.Activity, error)
+	RecordAttempt(attempt domain.DeliveryAttempt, maxFailures int) error
+}
+
+type Outbox interface {
+	Relay(limit int, publish func(event domain.OutboxEvent) error) (int, error)
+}
+
+// EventBus carries the events relayed from the outbox to their subscribers.
+type EventBus interface {
+	Publish(ctx context.Context, message eventbus.Message) error
+	Subscribe(ctx context.Context, group string, handler eventbus.Handler) error
+}
+
+type Sync interface {
+	GetChanges(userId int, cursor domain.SyncCursor, limit int) (domain.SyncChanges, domain.SyncCursor, error)
+	FindList(userId int, todoListId *int, clientId *string) (domain.TodoList, error)
+	FindItem(userId int, todoItemId *int, clientId *string) (domain.SyncedTodoItem, error)
+}
+
+type Idempotency interface {
+	Reserve(ctx context.Context, userId int, key string, fingerprint string,
+		ttl time.Duration) (domain.IdempotentResponse, bool, error)
+	Save(ctx context.Context, userId int, key string, response domain.IdempotentResponse, ttl time.Duration) error
+	Delete(ctx context.Context, userId int, key string) error
 }
 
 type Repository struct {
 	Authorization
 	TodoList
 	TodoItem
+	Trash
+	Template
+	Status
+	Assignee
+	Comment
+	Attachment
+	BlobStore
+	Activity
+	Events
+	Webhook
+	Outbox
+	EventBus
+	Sync
+	Idempotency
 }
 
-func NewRepository(db *sqlx.DB, rdb *redis.Client) *Repository {
+func NewRepository(db *sqlx.DB, rdb *redis.Client, blobs BlobStore, bus EventBus) *Repository {
 	return &Repository{
 		Authorization: NewAuthRepository(db, rdb),
 		TodoList:      NewTodoListRepository(db),
 		TodoItem:      NewTodoItemRepository(db),
+		Trash:         NewTrashRepository(db),
+		Template:      NewTemplateRepository(db),
+		Status:        NewStatusRepository(db),
+		Assignee:      NewAssigneeRepository(db),
+		Comment:       NewCommentRepository(db),
+		Attachment:    NewAttachmentRepository(db),
+		BlobStore:     blobs,
+		Activity:      NewActivityRepository(db),
+		Events:        NewEventRepository(rdb),
+		Webhook:       NewWebhookRepository(db),
+		Outbox:        NewOutboxRepository(db),
+		EventBus:      bus,
+		Sync:          NewSyncRepository(db),
+		Idempotency:   NewIdempotencyRepository(rdb),
 	}
 }
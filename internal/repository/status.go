@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type StatusRepository struct {
+	db *sqlx.DB
+}
+
+func NewStatusRepository(db *sqlx.DB) *StatusRepository {
+	return &StatusRepository{
+		db: db,
+	}
+}
+
+// createDefaultStatuses adds domain.DefaultStatuses to a freshly created list.
+func createDefaultStatuses(tx sqlx.Execer, todoListId int) error {
+	query := fmt.Sprintf(`INSERT INTO %s (list_id, name, position, is_done) VALUES ($1, $2, $3, $4)`,
+		listStatusesTable)
+	for _, status := range domain.DefaultStatuses {
+		if _, err := tx.Exec(query, todoListId, status.Name, status.Position, status.IsDone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncItemStatuses keeps the done flag and the status of the list's items
+// consistent. Items whose status is missing, belongs to another list or
+// disagrees with done get a column of this list: the one with the same name
+// if there is any, the first one matching done otherwise.
+func syncItemStatuses(tx sqlx.Execer, todoListId int) error {
+	query := fmt.Sprintf(`UPDATE %s ti SET status_id = COALESCE(
+		(SELECT ls.id FROM %s ls INNER JOIN %s cur ON cur.id = ti.status_id
+		WHERE ls.list_id = $1 AND ls.name = cur.name AND ls.is_done = ti.done LIMIT 1),
+		(SELECT ls.id FROM %s ls WHERE ls.list_id = $1 AND ls.is_done = ti.done ORDER BY ls.position, ls.id LIMIT 1)
+	) FROM %s li WHERE li.item_id = ti.id AND li.list_id = $1 AND NOT EXISTS
+	(SELECT 1 FROM %s ls WHERE ls.id = ti.status_id AND ls.list_id = $1 AND ls.is_done = ti.done)`,
+		todoItemsTable, listStatusesTable, listStatusesTable, listStatusesTable, listsItemsTable, listStatusesTable)
+	_, err := tx.Exec(query, todoListId)
+	return err
+}
+
+func (r *StatusRepository) GetAll(todoListId int) ([]domain.Status, error) {
+	statuses := make([]domain.Status, 0)
+
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE list_id = $1 ORDER BY position, id`, listStatusesTable)
+	err := r.db.Select(&statuses, query, todoListId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (r *StatusRepository) GetById(userId int, statusId int) (domain.Status, error) {
+	var status domain.Status
+
+	query := fmt.Sprintf(`SELECT ls.* FROM %s ls INNER JOIN %s tl ON tl.id = ls.list_id INNER JOIN %s ul
+	ON ul.list_id = ls.list_id WHERE ul.user_id = $1 AND ls.id = $2 AND tl.deleted_at IS NULL`,
+		listStatusesTable, todoListsTable, usersListsTable)
+	err := r.db.Get(&status, query, userId, statusId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return status, domain.ErrStatusNotFound
+		}
+		return status, err
+	}
+
+	return status, nil
+}
+
+func (r *StatusRepository) Create(todoListId int, status domain.Status) (int, error) {
+	var id int
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err = lockWritableList(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (list_id, name, position, is_done) VALUES ($1, $2,
+	COALESCE(NULLIF($3, 0), (SELECT COALESCE(MAX(position), 0) + 1 FROM %s WHERE list_id = $1)), $4)
+	RETURNING id`, listStatusesTable, listStatusesTable)
+	err = tx.QueryRow(query, todoListId, status.Name, status.Position, status.IsDone).Scan(&id)
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// lockStatusList keeps the list of the status from being archived until the
+// transaction ends, statuses of archived lists are read-only.
+func lockStatusList(tx *sqlx.Tx, statusId int) error {
+	query := fmt.Sprintf(`SELECT tl.archived FROM %s tl INNER JOIN %s ls ON ls.list_id = tl.id
+	WHERE ls.id = $1 AND tl.deleted_at IS NULL FOR SHARE OF tl`, todoListsTable, listStatusesTable)
+	return lockUnarchivedList(tx, domain.ErrStatusNotFound, query, statusId)
+}
+
+func (r *StatusRepository) Update(statusId int, updateStatus domain.UpdateStatus) (domain.Status, error) {
+	var status domain.Status
+
+	var names = make([]string, 0)
+	var values = make([]interface{}, 0)
+	var argId = 1
+
+	appendArg := func(name string, value interface{}) {
+		names = append(names, fmt.Sprintf("%s = $%d", name, argId))
+		values = append(values, value)
+		argId++
+	}
+
+	if updateStatus.Name != nil {
+		appendArg("name", *updateStatus.Name)
+	}
+	if updateStatus.Position != nil {
+		appendArg("position", *updateStatus.Position)
+	}
+	if updateStatus.IsDone != nil {
+		appendArg("is_done", *updateStatus.IsDone)
+	}
+	values = append(values, statusId)
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return status, err
+	}
+	defer tx.Rollback()
+
+	if err = lockStatusList(tx, statusId); err != nil {
+		logrus.Error(err)
+		return status, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $%d RETURNING *`, listStatusesTable,
+		strings.Join(names, ", "), argId)
+	if err = tx.Get(&status, query, values...); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return status, domain.ErrStatusNotFound
+		}
+		return status, err
+	}
+
+	todoItemIds := make([]int, 0)
+	query = fmt.Sprintf(`UPDATE %s SET done = $1 WHERE status_id = $2 AND done <> $1 RETURNING id`,
+		todoItemsTable)
+	if err = tx.Select(&todoItemIds, query, status.IsDone, status.Id); err != nil {
+		logrus.Error(err)
+		return status, err
+	}
+	for _, todoItemId := range todoItemIds {
+		if err = writeItemEvent(tx, domain.ActivityItemUpdated, status.ListId, todoItemId); err != nil {
+			logrus.Error(err)
+			return status, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return status, err
+	}
+	return status, nil
+}
+
+func (r *StatusRepository) Delete(statusId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = lockStatusList(tx, statusId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	var todoListId int
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING list_id`, listStatusesTable)
+	if err = tx.QueryRow(query, statusId).Scan(&todoListId); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrStatusNotFound
+		}
+		return err
+	}
+
+	if err = syncItemStatuses(tx, todoListId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *StatusRepository) SetItemStatus(userId int, todoItemId int, statusId int) (domain.TodoItem, error) {
+	var todoItem domain.TodoItem
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	defer tx.Rollback()
+
+	before, err := lockWritableItem(tx, userId, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s ti SET status_id = ls.id, done = ls.is_done FROM %s ls
+	WHERE ls.list_id = $1 AND ti.id = $2 AND ls.id = $3 RETURNING ti.*`, todoItemsTable, listStatusesTable)
+	err = tx.Get(&todoItem, query, before.ListId, todoItemId, statusId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoItem, domain.ErrItemNotFound
+		}
+		return todoItem, err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemUpdated, before.ListId, todoItem.Id); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = writeItemUpdate(tx, userId, before.ListId, before.TodoItem, todoItem); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return todoItem, err
+	}
+	return todoItem, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type SyncRepository struct {
+	db *sqlx.DB
+}
+
+func NewSyncRepository(db *sqlx.DB) *SyncRepository {
+	return &SyncRepository{
+		db: db,
+	}
+}
+
+// clientIdError maps the violation of the unique client id of a list or an
+// item to the domain error.
+func clientIdError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		if pqErr.Code == "23505" {
+			return domain.ErrClientIdTaken
+		}
+	}
+	return err
+}
+
+// GetChanges returns a page of at most limit lists, items and tombstones
+// of the user changed since cursor.Since, all of them if it is empty, with
+// the cursor of the next page. The cursors hold snapshots: a record is
+// changed since a snapshot when the transaction that last changed it is not
+// visible in it, so a transaction running for long only keeps its own
+// changes from being skipped. The snapshot of the first page is the Since
+// of the cursor returned with the last one. Records changed while the pages
+// are read may be returned again by the next sync.
+func (r *SyncRepository) GetChanges(userId int, cursor domain.SyncCursor,
+	limit int) (domain.SyncChanges, domain.SyncCursor, error) {
+	changes := domain.SyncChanges{
+		TodoLists: []domain.TodoList{},
+		TodoItems: []domain.SyncedTodoItem{},
+		Deleted:   []domain.SyncTombstone{},
+	}
+
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead,
+		ReadOnly: true})
+	if err != nil {
+		logrus.Error(err)
+		return changes, cursor, err
+	}
+	defer tx.Rollback()
+
+	// The first statement takes the snapshot the page is read from.
+	var snapshot string
+	err = tx.Get(&snapshot, `SELECT pg_current_snapshot()::text`)
+	if err != nil {
+		logrus.Error(err)
+		return changes, cursor, err
+	}
+	if cursor.Until == "" {
+		cursor.Until, cursor.Records = snapshot, domain.SyncList
+	}
+
+	var since *string
+	if cursor.Since != "" {
+		since = &cursor.Since
+	}
+
+	if cursor.Records == domain.SyncList {
+		query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id
+		LEFT JOIN %s sc ON sc.record_type = 'list' AND sc.record_id = tl.id WHERE ul.user_id = $1
+		AND CASE WHEN $2::pg_snapshot IS NULL THEN tl.deleted_at IS NULL
+		ELSE sc.change_xid >= pg_snapshot_xmin($2::pg_snapshot)
+		AND NOT pg_visible_in_snapshot(sc.change_xid, $2::pg_snapshot) END
+		AND tl.id > $3 ORDER BY tl.id LIMIT $4`, todoListsTable, usersListsTable, syncChangesTable)
+		if err = tx.Select(&changes.TodoLists, query, userId, since, cursor.RecordId, limit); err != nil {
+			logrus.Error(err)
+			return changes, cursor, err
+		}
+		if len(changes.TodoLists) == limit {
+			changes.HasMore = true
+			cursor.RecordId = changes.TodoLists[limit-1].Id
+			return changes, cursor, nil
+		}
+		limit -= len(changes.TodoLists)
+		cursor.Records, cursor.RecordId = domain.SyncItem, 0
+	}
+
+	if cursor.Records == domain.SyncItem {
+		// The items of a changed list are returned with it, so a client gets
+		// the items of a list shared with the user or restored from the
+		// trash.
+		query := fmt.Sprintf(`SELECT ti.*, li.list_id FROM %s ti INNER JOIN %s li ON li.item_id = ti.id
+		INNER JOIN %s tl ON tl.id = li.list_id
+		LEFT JOIN %s sc ON sc.record_type = 'item' AND sc.record_id = ti.id
+		LEFT JOIN %s lc ON lc.record_type = 'list' AND lc.record_id = tl.id
+		WHERE tl.deleted_at IS NULL AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = $1)
+		AND CASE WHEN $2::pg_snapshot IS NULL THEN ti.deleted_at IS NULL
+		ELSE sc.change_xid >= pg_snapshot_xmin($2::pg_snapshot)
+		AND NOT pg_visible_in_snapshot(sc.change_xid, $2::pg_snapshot)
+		OR lc.change_xid >= pg_snapshot_xmin($2::pg_snapshot)
+		AND NOT pg_visible_in_snapshot(lc.change_xid, $2::pg_snapshot) END
+		AND ti.id > $3 ORDER BY ti.id LIMIT $4`,
+			todoItemsTable, listsItemsTable, todoListsTable, syncChangesTable, syncChangesTable, usersListsTable)
+		if err = tx.Select(&changes.TodoItems, query, userId, since, cursor.RecordId, limit); err != nil {
+			logrus.Error(err)
+			return changes, cursor, err
+		}
+		if len(changes.TodoItems) == limit {
+			changes.HasMore = true
+			cursor.RecordId = changes.TodoItems[limit-1].Id
+			return changes, cursor, nil
+		}
+		limit -= len(changes.TodoItems)
+		cursor.Records, cursor.RecordId = domain.SyncDeleted, 0
+	}
+
+	if cursor.Records == domain.SyncDeleted && since != nil {
+		// A record the user has access to again is returned as changed
+		// instead.
+		query := fmt.Sprintf(`SELECT DISTINCT st.record_type, st.record_id FROM %s st
+		WHERE st.user_id = $1 AND st.change_xid >= pg_snapshot_xmin($2::pg_snapshot)
+		AND NOT pg_visible_in_snapshot(st.change_xid, $2::pg_snapshot)
+		AND NOT EXISTS (SELECT 1 FROM %s ul WHERE ul.user_id = $1 AND st.record_type = 'list'
+		AND ul.list_id = st.record_id)
+		AND NOT EXISTS (SELECT 1 FROM %s li INNER JOIN %s ul ON ul.list_id = li.list_id WHERE ul.user_id = $1
+		AND st.record_type = 'item' AND li.item_id = st.record_id)
+		AND (st.record_type, st.record_id) > ($3, $4)
+		ORDER BY st.record_type, st.record_id LIMIT $5`, syncTombstonesTable, usersListsTable, listsItemsTable,
+			usersListsTable)
+		err = tx.Select(&changes.Deleted, query, userId, since, cursor.RecordType, cursor.RecordId, limit)
+		if err != nil {
+			logrus.Error(err)
+			return changes, cursor, err
+		}
+		if len(changes.Deleted) == limit {
+			changes.HasMore = true
+			last := changes.Deleted[limit-1]
+			cursor.RecordType, cursor.RecordId = last.Type, last.Id
+			return changes, cursor, nil
+		}
+	}
+
+	return changes, domain.SyncCursor{Since: cursor.Until}, nil
+}
+
+// FindList returns the list with the id or the client id, or both when both
+// are set, lists in the trash included.
+func (r *SyncRepository) FindList(userId int, todoListId *int, clientId *string) (domain.TodoList, error) {
+	var todoList domain.TodoList
+
+	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id WHERE ul.user_id = $1
+	AND ($2::bigint IS NULL OR tl.id = $2) AND ($3::uuid IS NULL OR tl.client_id = $3)`,
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&todoList, query, userId, todoListId, clientId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoList, domain.ErrListNotFound
+		}
+		return todoList, err
+	}
+
+	return todoList, nil
+}
+
+// FindItem returns the item with the id or the client id, or both when both
+// are set, items in the trash included. An item of a list in the trash is
+// deleted with it.
+func (r *SyncRepository) FindItem(userId int, todoItemId *int, clientId *string) (domain.SyncedTodoItem, error) {
+	var todoItem domain.SyncedTodoItem
+
+	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done, ti.status_id, ti.version,
+	COALESCE(ti.deleted_at, tl.deleted_at) AS deleted_at, ti.client_id, li.list_id FROM %s ti
+	INNER JOIN %s li ON li.item_id = ti.id INNER JOIN %s tl ON tl.id = li.list_id
+	WHERE EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = li.list_id AND ul.user_id = $1)
+	AND ($2::bigint IS NULL OR ti.id = $2) AND ($3::uuid IS NULL OR ti.client_id = $3)`,
+		todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err := r.db.Get(&todoItem, query, userId, todoItemId, clientId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoItem, domain.ErrItemNotFound
+		}
+		return todoItem, err
+	}
+
+	return todoItem, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRepository_GetChanges(t *testing.T) {
+	listColumns := []string{"id", "title", "description", "archived", "pinned", "version", "deleted_at", "client_id"}
+	itemColumns := []string{"id", "title", "description", "done", "status_id", "version", "deleted_at", "client_id",
+		"list_id"}
+	snapshotRows := func(snapshot string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"pg_current_snapshot"}).AddRow(snapshot)
+	}
+
+	testTable := []struct {
+		name            string
+		cursor          domain.SyncCursor
+		limit           int
+		mockBehavior    func(mock sqlmock.Sqlmock)
+		expectedLists   []int
+		expectedItems   []int
+		expectedDeleted []domain.SyncTombstone
+		expectedHasMore bool
+		expectedCursor  domain.SyncCursor
+	}{
+		{
+			name:   "first page full of lists",
+			cursor: domain.SyncCursor{Since: "100:104:101"},
+			limit:  2,
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_current_snapshot").WillReturnRows(snapshotRows("110:112:"))
+				mock.ExpectQuery("SELECT tl.(.+) FROM todo_lists").WithArgs(1, "100:104:101", 0, 2).
+					WillReturnRows(sqlmock.NewRows(listColumns).
+						AddRow(1, "a", nil, false, false, 1, nil, nil).
+						AddRow(3, "b", nil, false, false, 1, nil, nil))
+				mock.ExpectRollback()
+			},
+			expectedLists:   []int{1, 3},
+			expectedItems:   []int{},
+			expectedDeleted: []domain.SyncTombstone{},
+			expectedHasMore: true,
+			expectedCursor: domain.SyncCursor{Since: "100:104:101", Until: "110:112:",
+				Records: domain.SyncList, RecordId: 3},
+		},
+		{
+			// The next sync starts from the snapshot of the first page, not
+			// from the one of the last page.
+			name: "last page",
+			cursor: domain.SyncCursor{Since: "100:104:101", Until: "110:112:",
+				Records: domain.SyncList, RecordId: 3},
+			limit: 3,
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_current_snapshot").WillReturnRows(snapshotRows("120:120:"))
+				mock.ExpectQuery("SELECT tl.(.+) FROM todo_lists").WithArgs(1, "100:104:101", 3, 3).
+					WillReturnRows(sqlmock.NewRows(listColumns).AddRow(4, "c", nil, false, false, 2, nil, nil))
+				mock.ExpectQuery("SELECT ti.(.+) FROM todo_items").WithArgs(1, "100:104:101", 0, 2).
+					WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(9, "d", nil, false, nil, 1, nil, nil, 4))
+				mock.ExpectQuery("SELECT DISTINCT (.+) FROM sync_tombstones").
+					WithArgs(1, "100:104:101", "", 0, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"record_type", "record_id"}))
+				mock.ExpectRollback()
+			},
+			expectedLists:   []int{4},
+			expectedItems:   []int{9},
+			expectedDeleted: []domain.SyncTombstone{},
+			expectedCursor:  domain.SyncCursor{Since: "110:112:"},
+		},
+		{
+			name: "page ends in the tombstones",
+			cursor: domain.SyncCursor{Since: "100:104:101", Until: "110:112:",
+				Records: domain.SyncDeleted, RecordType: domain.SyncItem, RecordId: 5},
+			limit: 1,
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_current_snapshot").WillReturnRows(snapshotRows("120:120:"))
+				mock.ExpectQuery("SELECT DISTINCT (.+) FROM sync_tombstones").
+					WithArgs(1, "100:104:101", domain.SyncItem, 5, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"record_type", "record_id"}).AddRow("list", 2))
+				mock.ExpectRollback()
+			},
+			expectedLists:   []int{},
+			expectedItems:   []int{},
+			expectedDeleted: []domain.SyncTombstone{{Type: domain.SyncList, Id: 2}},
+			expectedHasMore: true,
+			expectedCursor: domain.SyncCursor{Since: "100:104:101", Until: "110:112:",
+				Records: domain.SyncDeleted, RecordType: domain.SyncList, RecordId: 2},
+		},
+		{
+			name:  "first sync has no tombstones",
+			limit: 2,
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_current_snapshot").WillReturnRows(snapshotRows("110:112:"))
+				mock.ExpectQuery("SELECT tl.(.+) FROM todo_lists").WithArgs(1, nil, 0, 2).
+					WillReturnRows(sqlmock.NewRows(listColumns))
+				mock.ExpectQuery("SELECT ti.(.+) FROM todo_items").WithArgs(1, nil, 0, 2).
+					WillReturnRows(sqlmock.NewRows(itemColumns))
+				mock.ExpectRollback()
+			},
+			expectedLists:   []int{},
+			expectedItems:   []int{},
+			expectedDeleted: []domain.SyncTombstone{},
+			expectedCursor:  domain.SyncCursor{Since: "110:112:"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			testCase.mockBehavior(mock)
+			repo := NewSyncRepository(sqlx.NewDb(db, "postgres"))
+
+			changes, cursor, err := repo.GetChanges(1, testCase.cursor, testCase.limit)
+
+			assert.NoError(t, err)
+			lists, items := []int{}, []int{}
+			for _, todoList := range changes.TodoLists {
+				lists = append(lists, todoList.Id)
+			}
+			for _, todoItem := range changes.TodoItems {
+				items = append(items, todoItem.Id)
+			}
+			assert.Equal(t, testCase.expectedLists, lists)
+			assert.Equal(t, testCase.expectedItems, items)
+			assert.Equal(t, testCase.expectedDeleted, changes.Deleted)
+			assert.Equal(t, testCase.expectedHasMore, changes.HasMore)
+			assert.Equal(t, testCase.expectedCursor, cursor)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type TemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewTemplateRepository(db *sqlx.DB) *TemplateRepository {
+	return &TemplateRepository{
+		db: db,
+	}
+}
+
+func (r *TemplateRepository) CreateFromList(userId int, todoListId int, title *string) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, title, description) SELECT $1, COALESCE($3, tl.title),
+	tl.description FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id WHERE ul.user_id = $1 AND tl.id = $2
+	AND tl.deleted_at IS NULL RETURNING id`, listTemplatesTable, todoListsTable, usersListsTable)
+	if err = tx.QueryRow(query, userId, todoListId, title).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrListNotFound
+		}
+		return 0, err
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (template_id, title, description) SELECT $1, ti.title, ti.description
+	FROM %s ti INNER JOIN %s li ON li.item_id = ti.id WHERE li.list_id = $2 AND ti.deleted_at IS NULL
+	ORDER BY ti.id`, templateItemsTable, todoItemsTable, listsItemsTable)
+	if _, err = tx.Exec(query, id, todoListId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *TemplateRepository) GetAll(userId int) ([]domain.ListTemplate, error) {
+	var templates []domain.ListTemplate
+
+	query := fmt.Sprintf(`SELECT id, title, description FROM %s WHERE user_id = $1 ORDER BY id`,
+		listTemplatesTable)
+	err := r.db.Select(&templates, query, userId)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *TemplateRepository) GetById(userId int, templateId int) (domain.ListTemplate, error) {
+	var template domain.ListTemplate
+
+	query := fmt.Sprintf(`SELECT id, title, description FROM %s WHERE user_id = $1 AND id = $2`,
+		listTemplatesTable)
+	err := r.db.Get(&template, query, userId, templateId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return template, domain.ErrTemplateNotFound
+		}
+		return template, err
+	}
+
+	query = fmt.Sprintf(`SELECT id, title, description FROM %s WHERE template_id = $1 ORDER BY id`,
+		templateItemsTable)
+	err = r.db.Select(&template.Items, query, template.Id)
+	if err != nil {
+		logrus.Error(err)
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (r *TemplateRepository) Delete(userId int, templateId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1 AND id = $2 RETURNING id`, listTemplatesTable)
+
+	var id int
+	if err := r.db.QueryRow(query, userId, templateId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTemplateNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type TrashRepository struct {
+	db *sqlx.DB
+}
+
+func NewTrashRepository(db *sqlx.DB) *TrashRepository {
+	return &TrashRepository{
+		db: db,
+	}
+}
+
+func (r *TrashRepository) GetAll(userId int) (domain.Trash, error) {
+	trash := domain.Trash{
+		TodoLists: make([]domain.TodoList, 0),
+		TodoItems: make([]domain.TrashedTodoItem, 0),
+	}
+
+	query := fmt.Sprintf(`SELECT tl.* FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id
+	WHERE ul.user_id = $1 AND tl.deleted_at IS NOT NULL ORDER BY tl.deleted_at DESC`,
+		todoListsTable, usersListsTable)
+	if err := r.db.Select(&trash.TodoLists, query, userId); err != nil {
+		logrus.Error(err)
+		return trash, err
+	}
+
+	query = fmt.Sprintf(`SELECT ti.*, li.list_id FROM %s ti INNER JOIN %s li ON li.item_id = ti.id
+	INNER JOIN %s tl ON tl.id = li.list_id INNER JOIN %s ul ON ul.list_id = li.list_id
+	WHERE ul.user_id = $1 AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL
+	ORDER BY ti.deleted_at DESC`, todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	if err := r.db.Select(&trash.TodoItems, query, userId); err != nil {
+		logrus.Error(err)
+		return trash, err
+	}
+
+	return trash, nil
+}
+
+func (r *TrashRepository) RestoreList(userId int, todoListId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = restoreList(tx, userId, todoListId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeListEvent(tx, domain.ActivityListRestored, todoListId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeListActivity(tx, userId, todoListId, domain.ActivityListRestored, nil); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *TrashRepository) RestoreItem(userId int, todoItemId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	todoListId, err := restoreItem(tx, userId, todoItemId)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeItemEvent(tx, domain.ActivityItemRestored, todoListId, todoItemId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	err = writeItemActivity(tx, userId, todoListId, todoItemId, domain.ActivityItemRestored, nil)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func restoreList(q sqlx.Queryer, userId int, todoListId int) error {
+	query := fmt.Sprintf(`UPDATE %s tl SET deleted_at = NULL FROM %s ul WHERE ul.list_id = tl.id AND
+	ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NOT NULL RETURNING tl.id`, todoListsTable, usersListsTable)
+
+	var id int
+	if err := q.QueryRowx(query, userId, todoListId).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrListNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// restoreItem takes the item out of the trash and returns the list it is in.
+func restoreItem(q sqlx.Queryer, userId int, todoItemId int) (int, error) {
+	query := fmt.Sprintf(`UPDATE %s ti SET deleted_at = NULL FROM %s li, %s tl, %s ul WHERE
+	li.item_id = ti.id AND tl.id = li.list_id AND ul.list_id = li.list_id AND ul.user_id = $1 AND ti.id = $2
+	AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL RETURNING li.list_id`,
+		todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+
+	var todoListId int
+	if err := q.QueryRowx(query, userId, todoItemId).Scan(&todoListId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrItemNotFound
+		}
+		return 0, err
+	}
+
+	return todoListId, nil
+}
+
+func (r *TrashRepository) DeleteList(userId int, todoListId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := fmt.Sprintf(`SELECT tl.id FROM %s tl INNER JOIN %s ul ON ul.list_id = tl.id
+	WHERE ul.user_id = $1 AND tl.id = $2 AND tl.deleted_at IS NOT NULL FOR UPDATE OF tl`,
+		todoListsTable, usersListsTable)
+	if err = tx.QueryRow(query, userId, todoListId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrListNotFound
+		}
+		return err
+	}
+
+	query = fmt.Sprintf(`DELETE FROM %s ti USING %s li WHERE li.item_id = ti.id AND li.list_id = $1`,
+		todoItemsTable, listsItemsTable)
+	if _, err = tx.Exec(query, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	query = fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, todoListsTable)
+	if _, err = tx.Exec(query, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = writeListEvent(tx, domain.EventListPurged, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *TrashRepository) DeleteItem(userId int, todoItemId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s ul WHERE li.item_id = ti.id AND
+	li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NOT NULL
+	RETURNING ti.id, li.list_id`, todoItemsTable, listsItemsTable, usersListsTable)
+
+	var id, todoListId int
+	if err = tx.QueryRow(query, userId, todoItemId).Scan(&id, &todoListId); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrItemNotFound
+		}
+		return err
+	}
+
+	if err = writeItemEvent(tx, domain.EventItemPurged, todoListId, id); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// Purge deletes the records in the trash for longer than retention. The
+// cutoff is computed by the database, deleted_at holds its local time.
+func (r *TrashRepository) Purge(retention time.Duration) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// The items of a purged list go with it, the event of the list stands for
+	// them.
+	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s tl WHERE li.item_id = ti.id AND
+	tl.id = li.list_id AND tl.deleted_at < now() - $1::float8 * interval '1 second'`,
+		todoItemsTable, listsItemsTable, todoListsTable)
+	if _, err = tx.Exec(query, retention.Seconds()); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	todoListIds := make([]int, 0)
+	query = fmt.Sprintf(`DELETE FROM %s WHERE deleted_at < now() - $1::float8 * interval '1 second'
+	RETURNING id`, todoListsTable)
+	if err = tx.Select(&todoListIds, query, retention.Seconds()); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	for _, id := range todoListIds {
+		if err = writeListEvent(tx, domain.EventListPurged, id); err != nil {
+			logrus.Error(err)
+			return 0, err
+		}
+	}
+
+	var todoItems []struct {
+		Id     int `db:"id"`
+		ListId int `db:"list_id"`
+	}
+	query = fmt.Sprintf(`DELETE FROM %s ti USING %s li WHERE li.item_id = ti.id
+	AND ti.deleted_at < now() - $1::float8 * interval '1 second' RETURNING ti.id, li.list_id`,
+		todoItemsTable, listsItemsTable)
+	if err = tx.Select(&todoItems, query, retention.Seconds()); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	for _, todoItem := range todoItems {
+		if err = writeItemEvent(tx, domain.EventItemPurged, todoItem.ListId, todoItem.Id); err != nil {
+			logrus.Error(err)
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return len(todoListIds) + len(todoItems), nil
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// Undo reverts the activity and records the undo in one transaction. The
+// undo is recorded first, so of two undos of the same activity the second
+// fails before it changes anything. The record is then locked and compared
+// with the values the activity left behind, and reverted. Undo returns the id
+// of the activity recorded for the undo.
+func (r *ActivityRepository) Undo(userId int, activity domain.Activity) (int, error) {
+	undo, err := undoActivity(userId, activity)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err := writeActivity(tx, undo)
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	switch activity.Action {
+	case domain.ActivityListUpdated:
+		err = undoListUpdate(tx, userId, activity)
+	case domain.ActivityListDeleted:
+		err = undoListDelete(tx, userId, activity)
+	case domain.ActivityItemUpdated, domain.ActivityItemCompleted, domain.ActivityItemReopened:
+		err = undoItemUpdate(tx, userId, activity)
+	case domain.ActivityItemDeleted:
+		err = undoItemDelete(tx, userId, activity)
+	case domain.ActivityItemMoved:
+		err = undoItemMove(tx, userId, activity)
+	}
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// undoActivity returns the activity recording the undo. Its changes are the
+// ones of the activity reversed: the record is reverted only if it still has
+// the values the activity left behind.
+func undoActivity(userId int, activity domain.Activity) (domain.Activity, error) {
+	undo := domain.Activity{
+		ListId:  activity.ListId,
+		ItemId:  activity.ItemId,
+		Actor:   domain.ActivityActor{Id: userId},
+		Action:  activity.Action,
+		Changes: reverseChanges(activity.Changes),
+		UndoOf:  &activity.Id,
+	}
+
+	switch activity.Action {
+	case domain.ActivityListUpdated:
+		return undo, nil
+	case domain.ActivityListDeleted:
+		undo.Action = domain.ActivityListRestored
+		return undo, nil
+	case domain.ActivityItemUpdated, domain.ActivityItemCompleted, domain.ActivityItemReopened:
+		undo.Action = domain.ItemUpdateAction(undo.Changes)
+	case domain.ActivityItemDeleted:
+		undo.Action = domain.ActivityItemRestored
+	case domain.ActivityItemMoved:
+		// The move is recorded on the list that loses the item.
+		_, okSource := idValue(activity.Changes["listId"].Old)
+		todoListId, okDestination := idValue(activity.Changes["listId"].New)
+		if !okSource || !okDestination {
+			return undo, domain.ErrNotUndoable
+		}
+		undo.ListId = todoListId
+	default:
+		return undo, domain.ErrNotUndoable
+	}
+
+	if activity.ItemId == nil {
+		return undo, domain.ErrNotUndoable
+	}
+	return undo, nil
+}
+
+func reverseChanges(changes domain.Changes) domain.Changes {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	reversed := make(domain.Changes, len(changes))
+	for field, change := range changes {
+		reversed[field] = domain.Change{Old: change.New, New: change.Old}
+	}
+	return reversed
+}
+
+func undoListUpdate(tx *sqlx.Tx, userId int, activity domain.Activity) error {
+	before, err := lockList(tx, userId, activity.ListId)
+	if err != nil {
+		return err
+	}
+	if err = checkUndoConflicts(activity, domain.ListFields(before)); err != nil {
+		return err
+	}
+
+	// Title and flag changes are recorded separately, so an update never
+	// needs the list unarchived first.
+	if before.Archived && hasChanges(activity.Changes, "title", "description") {
+		return domain.ErrListArchived
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET title = $2, description = $3, archived = $4, pinned = $5 WHERE id = $1`,
+		todoListsTable)
+	_, err = tx.Exec(query, before.Id,
+		revertString(activity.Changes, "title", before.Title),
+		revertNullableString(activity.Changes, "description", before.Description),
+		revertBool(activity.Changes, "archived", before.Archived),
+		revertBool(activity.Changes, "pinned", before.Pinned))
+	if err != nil {
+		return err
+	}
+
+	return writeListEvent(tx, domain.ActivityListUpdated, before.Id)
+}
+
+func undoListDelete(tx *sqlx.Tx, userId int, activity domain.Activity) error {
+	if err := restoreList(tx, userId, activity.ListId); err != nil {
+		if domain.IsNotFound(err) {
+			if _, lockErr := lockList(tx, userId, activity.ListId); lockErr == nil {
+				return deletedConflict(activity)
+			}
+		}
+		return err
+	}
+
+	return writeListEvent(tx, domain.ActivityListRestored, activity.ListId)
+}
+
+func undoItemUpdate(tx *sqlx.Tx, userId int, activity domain.Activity) error {
+	before, err := lockItem(tx, userId, *activity.ItemId)
+	if err != nil {
+		return err
+	}
+	if before.ListArchived {
+		return domain.ErrListArchived
+	}
+	if err = checkUndoConflicts(activity, domain.ItemFields(before.TodoItem)); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET title = $2, description = $3, done = $4 WHERE id = $1`, todoItemsTable)
+	_, err = tx.Exec(query, before.Id,
+		revertString(activity.Changes, "title", before.Title),
+		revertNullableString(activity.Changes, "description", before.Description),
+		revertBool(activity.Changes, "done", before.Done))
+	if err != nil {
+		return err
+	}
+
+	// The old status may have been deleted since, then the item stays in
+	// the column matching its done flag.
+	if statusId, ok := idValue(activity.Changes["statusId"].Old); ok {
+		query = fmt.Sprintf(`UPDATE %s ti SET status_id = ls.id, done = ls.is_done FROM %s ls
+		WHERE ti.id = $1 AND ls.id = $2 AND ls.list_id = $3`, todoItemsTable, listStatusesTable)
+		if _, err = tx.Exec(query, before.Id, statusId, before.ListId); err != nil {
+			return err
+		}
+	}
+
+	if err = syncItemStatuses(tx, before.ListId); err != nil {
+		return err
+	}
+
+	return writeItemEvent(tx, domain.ActivityItemUpdated, before.ListId, before.Id)
+}
+
+func undoItemDelete(tx *sqlx.Tx, userId int, activity domain.Activity) error {
+	todoItemId := *activity.ItemId
+
+	if err := checkListWritable(tx, userId, activity.ListId); err != nil {
+		return err
+	}
+
+	todoListId, err := restoreItem(tx, userId, todoItemId)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			if _, lockErr := lockItem(tx, userId, todoItemId); lockErr == nil {
+				return deletedConflict(activity)
+			}
+		}
+		return err
+	}
+
+	return writeItemEvent(tx, domain.ActivityItemRestored, todoListId, todoItemId)
+}
+
+func undoItemMove(tx *sqlx.Tx, userId int, activity domain.Activity) error {
+	sourceListId, _ := idValue(activity.Changes["listId"].Old)
+
+	current, err := lockItem(tx, userId, *activity.ItemId)
+	if err != nil {
+		return err
+	}
+	if current.ListArchived {
+		return domain.ErrListArchived
+	}
+	if err = checkUndoConflicts(activity, map[string]interface{}{"listId": current.ListId}); err != nil {
+		return err
+	}
+	if err = checkListWritable(tx, userId, sourceListId); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET list_id = $2 WHERE item_id = $1`, listsItemsTable)
+	if _, err = tx.Exec(query, current.Id, sourceListId); err != nil {
+		return err
+	}
+
+	if err = syncItemStatuses(tx, sourceListId); err != nil {
+		return err
+	}
+
+	return writeItemEvent(tx, domain.ActivityItemMoved, sourceListId, current.Id)
+}
+
+// checkUndoConflicts compares the current values of the record with the
+// values the operation left behind.
+func checkUndoConflicts(activity domain.Activity, current map[string]interface{}) error {
+	conflicts := make([]domain.UndoConflict, 0)
+	for field, change := range activity.Changes {
+		if !sameValue(current[field], change.New) {
+			conflicts = append(conflicts, domain.UndoConflict{
+				Field:    field,
+				Expected: change.New,
+				Actual:   current[field],
+			})
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Field < conflicts[j].Field
+	})
+	return &domain.UndoConflictError{
+		ActivityId: activity.Id,
+		Conflicts:  conflicts,
+	}
+}
+
+func deletedConflict(activity domain.Activity) error {
+	return &domain.UndoConflictError{
+		ActivityId: activity.Id,
+		Conflicts: []domain.UndoConflict{
+			{Field: "deleted", Expected: true, Actual: false},
+		},
+	}
+}
+
+// sameValue compares values by their JSON encoding, changes read back from
+// the database hold numbers as float64.
+func sameValue(a interface{}, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func hasChanges(changes domain.Changes, fields ...string) bool {
+	for _, field := range fields {
+		if _, ok := changes[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// revertString returns the old value of the field, or current when the
+// field has not changed.
+func revertString(changes domain.Changes, field string, current string) string {
+	if value, ok := changes[field].Old.(string); ok {
+		return value
+	}
+	return current
+}
+
+func revertNullableString(changes domain.Changes, field string, current *string) *string {
+	if !hasChanges(changes, field) {
+		return current
+	}
+	value, ok := changes[field].Old.(string)
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func revertBool(changes domain.Changes, field string, current bool) bool {
+	if value, ok := changes[field].Old.(bool); ok {
+		return value
+	}
+	return current
+}
+
+func idValue(value interface{}) (int, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case float64:
+		return int(value), true
+	}
+	return 0, false
+}
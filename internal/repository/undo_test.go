@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUndoConflicts(t *testing.T) {
+	activity := domain.Activity{
+		Id: 7,
+		Changes: domain.Changes{
+			"title":    {Old: "old", New: "new"},
+			"statusId": {Old: float64(1), New: float64(2)},
+		},
+	}
+	statusId := 2
+
+	err := checkUndoConflicts(activity, domain.ItemFields(domain.TodoItem{Title: "new", StatusId: &statusId}))
+	assert.NoError(t, err)
+
+	err = checkUndoConflicts(activity, domain.ItemFields(domain.TodoItem{Title: "newer", StatusId: &statusId}))
+	assert.Equal(t, &domain.UndoConflictError{
+		ActivityId: 7,
+		Conflicts: []domain.UndoConflict{
+			{Field: "title", Expected: "new", Actual: "newer"},
+		},
+	}, err)
+}
+
+func TestActivityRepository_Undo(t *testing.T) {
+	itemId := 3
+	activity := domain.Activity{
+		Id:     7,
+		ListId: 1,
+		ItemId: &itemId,
+		Action: domain.ActivityItemUpdated,
+		Changes: domain.Changes{
+			"title": {Old: "old", New: "new"},
+		},
+	}
+	itemColumns := []string{"id", "title", "description", "done", "status_id", "version", "deleted_at",
+		"client_id", "list_id", "list_archived"}
+
+	testTable := []struct {
+		name          string
+		mockBehavior  func(mock sqlmock.Sqlmock)
+		expectedId    int
+		expectedError error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO activities").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "new", nil, false, 1, 2, nil, nil, 1, false))
+				mock.ExpectExec("UPDATE todo_items SET title").WithArgs(3, "old", nil, false).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE todo_items").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedId: 9,
+		},
+		{
+			// The undo is recorded before the item is compared, a conflict
+			// rolls the record back.
+			name: "changed since",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO activities").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_deliveries").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FOR UPDATE OF ti").WillReturnRows(sqlmock.NewRows(itemColumns).
+					AddRow(3, "newer", nil, false, 1, 3, nil, nil, 1, false))
+				mock.ExpectRollback()
+			},
+			expectedError: domain.ErrRecordChanged,
+		},
+		{
+			name: "already undone",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO activities").WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			expectedError: domain.ErrAlreadyUndone,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			testCase.mockBehavior(mock)
+			repo := NewActivityRepository(sqlx.NewDb(db, "postgres"))
+
+			id, err := repo.Undo(2, activity)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedId, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
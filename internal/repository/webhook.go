@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// The secret is left out, it is only returned when a webhook is created and
+// to the deliveries signed with it.
+const webhookColumns = `w.id, w.list_id, w.url, w.events, w.active, w.failures, w.created_at`
+
+const deliveryColumns = `d.id, d.webhook_id, d.activity_id, d.event, d.status, d.attempts, d.response_code,
+	d.error, d.next_attempt_at, d.created_at, d.delivered_at`
+
+// webhookRow scans the events of a webhook, which are stored as an array.
+type webhookRow struct {
+	domain.Webhook
+	Events pq.StringArray `db:"events"`
+}
+
+func (r webhookRow) webhook() domain.Webhook {
+	webhook := r.Webhook
+	webhook.Events = r.Events
+	return webhook
+}
+
+func (r *WebhookRepository) Create(userId int, webhook domain.Webhook) (int, error) {
+	var id int
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err = lockWritableList(tx, webhook.ListId); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (list_id, user_id, url, secret, events) VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`, webhooksTable)
+	err = tx.QueryRow(query, webhook.ListId, userId, webhook.URL, webhook.Secret,
+		pq.Array(webhook.Events)).Scan(&id)
+	if err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// lockWebhookList keeps the list of the webhook from being archived until
+// the transaction ends, webhooks of archived lists are read-only.
+func lockWebhookList(tx *sqlx.Tx, webhookId int) error {
+	query := fmt.Sprintf(`SELECT tl.archived FROM %s tl INNER JOIN %s w ON w.list_id = tl.id
+	WHERE w.id = $1 FOR SHARE OF tl`, todoListsTable, webhooksTable)
+	return lockUnarchivedList(tx, domain.ErrWebhookNotFound, query, webhookId)
+}
+
+func (r *WebhookRepository) GetAll(todoListId int) ([]domain.Webhook, error) {
+	var rows []webhookRow
+
+	query := fmt.Sprintf(`SELECT %s FROM %s w WHERE w.list_id = $1 ORDER BY w.id`, webhookColumns, webhooksTable)
+	if err := r.db.Select(&rows, query, todoListId); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	webhooks := make([]domain.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, row.webhook())
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetById(userId int, webhookId int) (domain.Webhook, error) {
+	var row webhookRow
+
+	query := fmt.Sprintf(`SELECT %s FROM %s w WHERE w.id = $2 AND EXISTS (SELECT 1 FROM %s ul
+	WHERE ul.list_id = w.list_id AND ul.user_id = $1)`, webhookColumns, webhooksTable, usersListsTable)
+	err := r.db.Get(&row, query, userId, webhookId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, err
+	}
+
+	return row.webhook(), nil
+}
+
+// Update replaces the settings of a webhook. Activating a webhook resets its
+// failures.
+func (r *WebhookRepository) Update(webhookId int, input domain.UpdateWebhook) (domain.Webhook, error) {
+	var row webhookRow
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return domain.Webhook{}, err
+	}
+	defer tx.Rollback()
+
+	if err = lockWebhookList(tx, webhookId); err != nil {
+		logrus.Error(err)
+		return domain.Webhook{}, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s w SET url = $2, events = $3, active = $4,
+	failures = CASE WHEN $4 AND NOT w.active THEN 0 ELSE w.failures END WHERE w.id = $1 RETURNING %s`,
+		webhooksTable, webhookColumns)
+	err = tx.Get(&row, query, webhookId, input.URL, pq.Array(input.Events), input.Active)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return domain.Webhook{}, err
+	}
+	return row.webhook(), nil
+}
+
+func (r *WebhookRepository) Delete(webhookId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = lockWebhookList(tx, webhookId); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING id`, webhooksTable)
+	var id int
+	if err = tx.QueryRow(query, webhookId).Scan(&id); err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrWebhookNotFound
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// enqueueDeliveries queues a delivery of the activity to every active
+// webhook of its list that subscribed to its action. It runs in the
+// transaction recording the activity, so a committed activity is always
+// delivered.
+func enqueueDeliveries(tx sqlx.Execer, activityId int, todoListId int, action string) error {
+	query := fmt.Sprintf(`INSERT INTO %s (webhook_id, activity_id, event) SELECT w.id, $1, $3 FROM %s w
+	WHERE w.list_id = $2 AND w.active AND $3 = ANY(w.events)`, webhookDeliveriesTable, webhooksTable)
+	_, err := tx.Exec(query, activityId, todoListId, action)
+	return err
+}
+
+func (r *WebhookRepository) GetDeliveries(webhookId int, limit int, offset int) ([]domain.WebhookDelivery,
+	int, error) {
+	deliveries := make([]domain.WebhookDelivery, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s d WHERE d.webhook_id = $1 ORDER BY d.created_at DESC, d.id DESC
+	LIMIT $2 OFFSET $3`, deliveryColumns, webhookDeliveriesTable)
+	if err := r.db.Select(&deliveries, query, webhookId, limit, offset); err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	var total int
+	query = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE webhook_id = $1`, webhookDeliveriesTable)
+	if err := r.db.Get(&total, query, webhookId); err != nil {
+		logrus.Error(err)
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *WebhookRepository) GetDeliveryById(userId int, deliveryId int) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	query := fmt.Sprintf(`SELECT %s FROM %s d INNER JOIN %s w ON w.id = d.webhook_id WHERE d.id = $2
+	AND EXISTS (SELECT 1 FROM %s ul WHERE ul.list_id = w.list_id AND ul.user_id = $1)`,
+		deliveryColumns, webhookDeliveriesTable, webhooksTable, usersListsTable)
+	err := r.db.Get(&delivery, query, userId, deliveryId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return delivery, domain.ErrDeliveryNotFound
+		}
+		return delivery, err
+	}
+
+	return delivery, nil
+}
+
+// Redeliver queues a new delivery of the same activity, the log keeps the
+// attempts of the original one.
+func (r *WebhookRepository) Redeliver(deliveryId int) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	query := fmt.Sprintf(`INSERT INTO %s AS d (webhook_id, activity_id, event) SELECT webhook_id, activity_id, event
+	FROM %s WHERE id = $1 RETURNING %s`, webhookDeliveriesTable, webhookDeliveriesTable, deliveryColumns)
+	err := r.db.Get(&delivery, query, deliveryId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return delivery, domain.ErrDeliveryNotFound
+		}
+		return delivery, err
+	}
+
+	return delivery, nil
+}
+
+// ClaimDeliveries returns up to limit pending deliveries that are due, oldest
+// first, and postpones them by lease, so other instances skip them while they
+// are sent. A delivery whose attempt is never recorded is retried once the
+// lease ends.
+func (r *WebhookRepository) ClaimDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	deliveries := make([]domain.PendingDelivery, 0)
+
+	query := fmt.Sprintf(`WITH claimed AS (UPDATE %s d SET next_attempt_at = now() + $2::float8 * interval '1 second'
+	WHERE d.id IN (SELECT d.id FROM %s d INNER JOIN %s w ON w.id = d.webhook_id WHERE d.status = $3
+	AND d.next_attempt_at <= now() AND w.active ORDER BY d.next_attempt_at, d.id LIMIT $1
+	FOR UPDATE OF d SKIP LOCKED) RETURNING %s)
+	SELECT d.*, w.url, w.secret FROM claimed d INNER JOIN %s w ON w.id = d.webhook_id
+	ORDER BY d.created_at, d.id`, webhookDeliveriesTable, webhookDeliveriesTable, webhooksTable,
+		deliveryColumns, webhooksTable)
+	err := r.db.Select(&deliveries, query, limit, lease.Seconds(), domain.DeliveryPending)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// GetActivity returns an activity of any list, for the payload of a
+// delivery.
+func (r *WebhookRepository) GetActivity(activityId int) (domain.Activity, error) {
+	var activity domain.Activity
+
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s u ON u.id = a.user_id LEFT JOIN %s ua
+	ON ua.undo_of = a.id WHERE a.id = $1`, activityColumns, activitiesTable, usersTable, activitiesTable)
+	err := r.db.Get(&activity, query, activityId)
+	if err != nil {
+		logrus.Error(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return activity, domain.ErrActivityNotFound
+		}
+		return activity, err
+	}
+
+	return activity, nil
+}
+
+// RecordAttempt saves the outcome of sending a delivery and counts the
+// consecutive failures of its webhook, which is disabled once they reach
+// maxFailures.
+func (r *WebhookRepository) RecordAttempt(attempt domain.DeliveryAttempt, maxFailures int) error {
+	status := domain.DeliveryPending
+	switch {
+	case attempt.Succeeded:
+		status = domain.DeliverySucceeded
+	case attempt.NextAttemptAt == nil:
+		status = domain.DeliveryFailed
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`UPDATE %s SET status = $2, attempts = attempts + 1, response_code = $3, error = $4,
+	next_attempt_at = COALESCE($5, next_attempt_at), delivered_at = CASE WHEN $6 THEN now() END
+	WHERE id = $1`, webhookDeliveriesTable)
+	_, err = tx.Exec(query, attempt.DeliveryId, status, attempt.ResponseCode, attempt.Error,
+		attempt.NextAttemptAt, attempt.Succeeded)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if attempt.Succeeded {
+		query = fmt.Sprintf(`UPDATE %s SET failures = 0 WHERE id = $1`, webhooksTable)
+		_, err = tx.Exec(query, attempt.WebhookId)
+	} else {
+		query = fmt.Sprintf(`UPDATE %s SET failures = failures + 1, active = active AND failures + 1 < $2
+		WHERE id = $1`, webhooksTable)
+		_, err = tx.Exec(query, attempt.WebhookId, maxFailures)
+	}
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+
+	todov1 "github.com/IvanMeln1k/go-todo-app/api/todo/v1"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type authServer struct {
+	todov1.UnimplementedAuthServiceServer
+	*Handler
+}
+
+type signInInput struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (s *authServer) SignUp(ctx context.Context, req *todov1.SignUpRequest) (*todov1.SignUpResponse, error) {
+	user := domain.User{Name: req.Name, Username: req.Username, Password: req.Password}
+	if err := s.validate(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.CreateUser(user)
+	if err != nil {
+		return nil, err
+	}
+	return &todov1.SignUpResponse{Id: int64(id)}, nil
+}
+
+func (s *authServer) SignIn(ctx context.Context, req *todov1.SignInRequest) (*todov1.Tokens, error) {
+	input := signInInput{Username: req.Username, Password: req.Password}
+	if err := s.validate(ctx, &input); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.services.Authorization.SignIn(ctx, input.Username, input.Password)
+	if err != nil {
+		return nil, err
+	}
+	return tokensMessage(tokens), nil
+}
+
+func (s *authServer) Refresh(ctx context.Context, req *todov1.RefreshRequest) (*todov1.Tokens, error) {
+	if req.RefreshToken == "" {
+		return nil, errUnauthorized
+	}
+
+	tokens, err := s.services.Authorization.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return tokensMessage(tokens), nil
+}
+
+func (s *authServer) Logout(ctx context.Context, req *todov1.LogoutRequest) (*emptypb.Empty, error) {
+	if req.RefreshToken == "" {
+		return nil, errUnauthorized
+	}
+	if err := s.services.Authorization.Logout(ctx, req.RefreshToken); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *authServer) LogoutAll(ctx context.Context, req *todov1.LogoutRequest) (*emptypb.Empty, error) {
+	if req.RefreshToken == "" {
+		return nil, errUnauthorized
+	}
+	if err := s.services.Authorization.LogoutAll(ctx, req.RefreshToken); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func tokensMessage(tokens service.Tokens) *todov1.Tokens {
+	return &todov1.Tokens{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of statuses, their
+// reason is the code the REST API reports.
+const errorDomain = "todo-app"
+
+var errUnauthorized = domain.NewUnauthorizedError("unauthorized", "Unauthorized")
+
+var kindCodes = map[domain.ErrorKind]codes.Code{
+	domain.KindNotFound:             codes.NotFound,
+	domain.KindConflict:             codes.FailedPrecondition,
+	domain.KindForbidden:            codes.PermissionDenied,
+	domain.KindValidation:           codes.InvalidArgument,
+	domain.KindUnauthorized:         codes.Unauthenticated,
+	domain.KindPreconditionFailed:   codes.Aborted,
+	domain.KindTooLarge:             codes.ResourceExhausted,
+	domain.KindUnsupportedMediaType: codes.InvalidArgument,
+}
+
+// statusError turns an error into a status. Domain errors keep their code
+// and invalid fields in the details, other errors are logged and reported
+// without their details.
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		logrus.Error(err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	st, detailsErr := status.New(kindCodes[domainErr.Kind], domainErr.Message).
+		WithDetails(&errdetails.ErrorInfo{Reason: domainErr.Code, Domain: errorDomain})
+	if detailsErr != nil {
+		logrus.Error(detailsErr)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+	if len(domainErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range domainErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		if st, detailsErr = st.WithDetails(badRequest); detailsErr != nil {
+			logrus.Error(detailsErr)
+			return status.Error(codes.Internal, "Internal server error")
+		}
+	}
+	return st.Err()
+}
+
+func unaryErrors(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return resp, nil
+}
+
+func streamErrors(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := handler(srv, stream); err != nil {
+		return statusError(err)
+	}
+	return nil
+}
+
+// validate checks the input of a call, with messages in the language of the
+// "accept-language" metadata.
+func (h *Handler) validate(ctx context.Context, input interface{}) error {
+	err := h.validator.Validate(input)
+	if err == nil {
+		return nil
+	}
+
+	var acceptLanguage string
+	if values := metadata.ValueFromIncomingContext(ctx, "accept-language"); len(values) > 0 {
+		acceptLanguage = values[0]
+	}
+	fieldErrs, ok := h.validator.FieldErrors(err, acceptLanguage)
+	if !ok {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	fields := make([]domain.FieldError, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		fields = append(fields, domain.FieldError{
+			Field:   fieldErr.Field,
+			Rule:    fieldErr.Rule,
+			Param:   fieldErr.Param,
+			Message: fieldErr.Message,
+		})
+	}
+	return domain.ErrValidation.WithFields(fields...)
+}
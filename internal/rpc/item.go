@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+
+	todov1 "github.com/IvanMeln1k/go-todo-app/api/todo/v1"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type itemServer struct {
+	todov1.UnimplementedItemServiceServer
+	*Handler
+}
+
+func (s *itemServer) CreateItem(ctx context.Context, req *todov1.CreateItemRequest) (*todov1.TodoItem, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoItem := domain.TodoItem{Title: req.Title, Description: req.Description}
+	if err = s.validate(ctx, &todoItem); err != nil {
+		return nil, err
+	}
+
+	todoItemId, err := s.services.TodoItem.Create(userId, int(req.ListId), todoItem)
+	if err != nil {
+		return nil, err
+	}
+	return s.getItem(userId, todoItemId)
+}
+
+func (s *itemServer) ListItems(ctx context.Context, req *todov1.ListItemsRequest) (*todov1.ListItemsResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoItems, err := s.services.TodoItem.GetAll(userId, int(req.ListId))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &todov1.ListItemsResponse{Items: make([]*todov1.TodoItem, 0, len(todoItems))}
+	for _, todoItem := range todoItems {
+		resp.Items = append(resp.Items, itemMessage(todoItem))
+	}
+	return resp, nil
+}
+
+func (s *itemServer) GetItem(ctx context.Context, req *todov1.GetItemRequest) (*todov1.TodoItem, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.getItem(userId, int(req.Id))
+}
+
+func (s *itemServer) ReplaceItem(ctx context.Context, req *todov1.ReplaceItemRequest) (*todov1.TodoItem, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := domain.ReplaceTodoItem{
+		Title:       req.Title,
+		Description: req.Description,
+		Done:        req.Done,
+		Version:     optionalInt(req.Version),
+	}
+	if err = s.validate(ctx, &replacement); err != nil {
+		return nil, err
+	}
+
+	todoItem, err := s.services.TodoItem.Replace(userId, int(req.Id), replacement)
+	if err != nil {
+		return nil, err
+	}
+	return itemMessage(todoItem), nil
+}
+
+func (s *itemServer) DeleteItem(ctx context.Context, req *todov1.DeleteItemRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.services.TodoItem.Delete(userId, int(req.Id), optionalInt(req.Version)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) MoveItem(ctx context.Context, req *todov1.MoveItemRequest) (*todov1.TodoItem, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoItem, err := s.services.TodoItem.Move(userId, int(req.Id), int(req.ListId))
+	if err != nil {
+		return nil, err
+	}
+	return itemMessage(todoItem), nil
+}
+
+func (s *itemServer) CopyItem(ctx context.Context, req *todov1.MoveItemRequest) (*todov1.TodoItem, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoItemId, err := s.services.TodoItem.Copy(userId, int(req.Id), int(req.ListId))
+	if err != nil {
+		return nil, err
+	}
+	return s.getItem(userId, todoItemId)
+}
+
+func (s *itemServer) getItem(userId int, todoItemId int) (*todov1.TodoItem, error) {
+	todoItem, err := s.services.TodoItem.GetById(userId, todoItemId)
+	if err != nil {
+		return nil, err
+	}
+	return itemMessage(todoItem), nil
+}
+
+func itemMessage(todoItem domain.TodoItem) *todov1.TodoItem {
+	return &todov1.TodoItem{
+		Id:          int64(todoItem.Id),
+		Title:       todoItem.Title,
+		Description: todoItem.Description,
+		Done:        todoItem.Done,
+		StatusId:    optionalInt64(todoItem.StatusId),
+		Version:     int64(todoItem.Version),
+	}
+}
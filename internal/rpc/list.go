@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+
+	todov1 "github.com/IvanMeln1k/go-todo-app/api/todo/v1"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type listServer struct {
+	todov1.UnimplementedListServiceServer
+	*Handler
+}
+
+func (s *listServer) CreateList(ctx context.Context, req *todov1.CreateListRequest) (*todov1.TodoList, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoList := domain.TodoList{Title: req.Title, Description: req.Description}
+	if err = s.validate(ctx, &todoList); err != nil {
+		return nil, err
+	}
+
+	todoListId, err := s.services.TodoList.Create(userId, todoList)
+	if err != nil {
+		return nil, err
+	}
+	return s.getList(userId, todoListId)
+}
+
+func (s *listServer) ListLists(ctx context.Context, req *todov1.ListListsRequest) (*todov1.ListListsResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoLists, err := s.services.TodoList.GetAll(userId, req.Archived)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &todov1.ListListsResponse{Lists: make([]*todov1.TodoList, 0, len(todoLists))}
+	for _, todoList := range todoLists {
+		resp.Lists = append(resp.Lists, listMessage(todoList))
+	}
+	return resp, nil
+}
+
+func (s *listServer) GetList(ctx context.Context, req *todov1.GetListRequest) (*todov1.TodoList, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.getList(userId, int(req.Id))
+}
+
+func (s *listServer) ReplaceList(ctx context.Context, req *todov1.ReplaceListRequest) (*todov1.TodoList, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := domain.ReplaceTodoList{
+		Title:       req.Title,
+		Description: req.Description,
+		Version:     optionalInt(req.Version),
+	}
+	if err = s.validate(ctx, &replacement); err != nil {
+		return nil, err
+	}
+
+	todoList, err := s.services.TodoList.Replace(userId, int(req.Id), replacement)
+	if err != nil {
+		return nil, err
+	}
+	return listMessage(todoList), nil
+}
+
+func (s *listServer) DeleteList(ctx context.Context, req *todov1.DeleteListRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.services.TodoList.Delete(userId, int(req.Id), optionalInt(req.Version)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) ArchiveList(ctx context.Context, req *todov1.GetListRequest) (*todov1.TodoList, error) {
+	return s.changeList(ctx, req, s.services.TodoList.Archive)
+}
+
+func (s *listServer) UnarchiveList(ctx context.Context, req *todov1.GetListRequest) (*todov1.TodoList, error) {
+	return s.changeList(ctx, req, s.services.TodoList.Unarchive)
+}
+
+func (s *listServer) PinList(ctx context.Context, req *todov1.GetListRequest) (*todov1.TodoList, error) {
+	return s.changeList(ctx, req, s.services.TodoList.Pin)
+}
+
+func (s *listServer) UnpinList(ctx context.Context, req *todov1.GetListRequest) (*todov1.TodoList, error) {
+	return s.changeList(ctx, req, s.services.TodoList.Unpin)
+}
+
+func (s *listServer) changeList(ctx context.Context, req *todov1.GetListRequest,
+	change func(userId int, todoListId int) (domain.TodoList, error)) (*todov1.TodoList, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoList, err := change(userId, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return listMessage(todoList), nil
+}
+
+func (s *listServer) getList(userId int, todoListId int) (*todov1.TodoList, error) {
+	todoList, err := s.services.TodoList.GetById(userId, todoListId)
+	if err != nil {
+		return nil, err
+	}
+	return listMessage(todoList), nil
+}
+
+func listMessage(todoList domain.TodoList) *todov1.TodoList {
+	return &todov1.TodoList{
+		Id:          int64(todoList.Id),
+		Title:       todoList.Title,
+		Description: todoList.Description,
+		Archived:    todoList.Archived,
+		Pinned:      todoList.Pinned,
+		Version:     int64(todoList.Version),
+	}
+}
@@ -0,0 +1,136 @@
+// Package rpc serves the gRPC API. It calls the same services as the REST
+// handlers, so both transports behave the same and report the same errors.
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	todov1 "github.com/IvanMeln1k/go-todo-app/api/todo/v1"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+type Handler struct {
+	services  *service.Service
+	validator *validate.CustomValidator
+}
+
+func NewHandler(services *service.Service) *Handler {
+	return &Handler{
+		services:  services,
+		validator: validate.NewCustomValidator(),
+	}
+}
+
+// publicServices are served without an access token.
+var publicServices = map[string]bool{
+	todov1.AuthService_ServiceDesc.ServiceName: true,
+	healthpb.Health_ServiceDesc.ServiceName:    true,
+	"grpc.reflection.v1.ServerReflection":      true,
+	"grpc.reflection.v1alpha.ServerReflection": true,
+}
+
+func (h *Handler) InitServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryErrors, h.unaryIdentity),
+		grpc.ChainStreamInterceptor(streamErrors, h.streamIdentity),
+	)
+
+	todov1.RegisterAuthServiceServer(server, &authServer{Handler: h})
+	todov1.RegisterListServiceServer(server, &listServer{Handler: h})
+	todov1.RegisterItemServiceServer(server, &itemServer{Handler: h})
+
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server
+}
+
+type userIdKey struct{}
+
+// identity checks the access token of a call to a service that requires one
+// and puts the id of the user in the context, like the userIdentity
+// middleware of the REST API.
+func (h *Handler) identity(ctx context.Context, fullMethod string) (context.Context, error) {
+	serviceName, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if publicServices[serviceName] {
+		return ctx, nil
+	}
+
+	authorization := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(authorization) == 0 {
+		return nil, errUnauthorized
+	}
+	scheme, token, ok := strings.Cut(authorization[0], " ")
+	if !ok || scheme != "Bearer" {
+		return nil, errUnauthorized
+	}
+
+	userId, err := h.services.Authorization.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, userIdKey{}, userId), nil
+}
+
+func (h *Handler) unaryIdentity(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := h.identity(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (h *Handler) streamIdentity(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := h.identity(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func getUserId(ctx context.Context) (int, error) {
+	userId, ok := ctx.Value(userIdKey{}).(int)
+	if !ok {
+		return 0, errUnauthorized
+	}
+	return userId, nil
+}
+
+// optionalInt converts an optional field of a request.
+func optionalInt(n *int64) *int {
+	if n == nil {
+		return nil
+	}
+	i := int(*n)
+	return &i
+}
+
+func optionalInt64(n *int) *int64 {
+	if n == nil {
+		return nil
+	}
+	i := int64(*n)
+	return &i
+}
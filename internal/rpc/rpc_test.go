@@ -0,0 +1,155 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	todov1 "github.com/IvanMeln1k/go-todo-app/api/todo/v1"
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dial(t *testing.T, services *service.Service) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := NewHandler(services).InitServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestListServer_GetList(t *testing.T) {
+	type mockBehavior func(auth *mock_service.MockAuthorization, todoList *mock_service.MockTodoList)
+
+	testTable := []struct {
+		name           string
+		authorization  string
+		mockBehavior   mockBehavior
+		expectedCode   codes.Code
+		expectedReason string
+		expectedList   *todov1.TodoList
+	}{
+		{
+			name:          "ok",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mock_service.MockAuthorization, todoList *mock_service.MockTodoList) {
+				auth.EXPECT().ParseToken("token").Return(1, nil)
+				todoList.EXPECT().GetById(1, 2).Return(domain.TodoList{Id: 2, Title: "list", Version: 3}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedList: &todov1.TodoList{Id: 2, Title: "list", Version: 3},
+		},
+		{
+			name:           "no token",
+			mockBehavior:   func(auth *mock_service.MockAuthorization, todoList *mock_service.MockTodoList) {},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "unauthorized",
+		},
+		{
+			name:          "expired token",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mock_service.MockAuthorization, todoList *mock_service.MockTodoList) {
+				auth.EXPECT().ParseToken("token").Return(0, service.ErrTokenExpired)
+			},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: "token_expired",
+		},
+		{
+			name:          "not found",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mock_service.MockAuthorization, todoList *mock_service.MockTodoList) {
+				auth.EXPECT().ParseToken("token").Return(1, nil)
+				todoList.EXPECT().GetById(1, 2).Return(domain.TodoList{}, domain.ErrListNotFound)
+			},
+			expectedCode:   codes.NotFound,
+			expectedReason: "list_not_found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			todoList := mock_service.NewMockTodoList(c)
+			testCase.mockBehavior(auth, todoList)
+
+			client := todov1.NewListServiceClient(dial(t, &service.Service{Authorization: auth, TodoList: todoList}))
+
+			ctx := context.Background()
+			if testCase.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", testCase.authorization)
+			}
+			resp, err := client.GetList(ctx, &todov1.GetListRequest{Id: 2})
+
+			st := status.Convert(err)
+			assert.Equal(t, testCase.expectedCode, st.Code())
+			if testCase.expectedList != nil {
+				assert.Equal(t, testCase.expectedList.String(), resp.String())
+			}
+			if testCase.expectedReason != "" && assert.Len(t, st.Details(), 1) {
+				info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				if assert.True(t, ok) {
+					assert.Equal(t, testCase.expectedReason, info.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestListServer_CreateList_validation(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	auth := mock_service.NewMockAuthorization(c)
+	auth.EXPECT().ParseToken("token").Return(1, nil)
+
+	client := todov1.NewListServiceClient(dial(t, &service.Service{Authorization: auth}))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token",
+		"accept-language", "ru")
+	_, err := client.CreateList(ctx, &todov1.CreateListRequest{})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Validation failed", st.Message())
+	assert.Len(t, st.Details(), 2)
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	if assert.True(t, ok) && assert.Len(t, badRequest.FieldViolations, 1) {
+		assert.Equal(t, "title", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "поле title обязательно для заполнения", badRequest.FieldViolations[0].Description)
+	}
+}
+
+func TestHandler_health(t *testing.T) {
+	client := healthpb.NewHealthClient(dial(t, &service.Service{}))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: todov1.ListService_ServiceDesc.ServiceName,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
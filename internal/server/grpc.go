@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCServer serves the gRPC API on its own port, next to Server.
+type GRPCServer struct {
+	grpcServer *grpc.Server
+}
+
+func (s *GRPCServer) Run(port string, grpcServer *grpc.Server) error {
+	listener, err := net.Listen("tcp", "127.0.0.1:"+port)
+	if err != nil {
+		return err
+	}
+
+	s.grpcServer = grpcServer
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown waits for the running calls to finish, until ctx is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
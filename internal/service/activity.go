@@ -0,0 +1,51 @@
+package service
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+type ActivityService struct {
+	repo     repository.Activity
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+}
+
+func NewActivityService(repo repository.Activity, listRepo repository.TodoList,
+	itemRepo repository.TodoItem) *ActivityService {
+	return &ActivityService{
+		repo:     repo,
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *ActivityService) GetByList(userId int, todoListId int, limit int, offset int) (domain.ActivityPage, error) {
+	page := domain.ActivityPage{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	todoList, err := s.listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return page, err
+	}
+
+	page.Activities, page.Total, err = s.repo.GetByList(todoList.Id, limit, offset)
+	return page, err
+}
+
+func (s *ActivityService) GetByItem(userId int, todoItemId int, limit int, offset int) (domain.ActivityPage, error) {
+	page := domain.ActivityPage{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	todoItem, err := s.itemRepo.GetById(userId, todoItemId)
+	if err != nil {
+		return page, err
+	}
+
+	page.Activities, page.Total, err = s.repo.GetByItem(todoItem.Id, limit, offset)
+	return page, err
+}
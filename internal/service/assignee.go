@@ -0,0 +1,67 @@
+package service
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+type AssigneeService struct {
+	repo     repository.Assignee
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+}
+
+func NewAssigneeService(repo repository.Assignee, listRepo repository.TodoList,
+	itemRepo repository.TodoItem) *AssigneeService {
+	return &AssigneeService{
+		repo:     repo,
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *AssigneeService) GetAll(userId int, todoItemId int) ([]domain.Assignee, error) {
+	todoItem, err := s.itemRepo.GetById(userId, todoItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAll(todoItem.Id)
+}
+
+func (s *AssigneeService) GetListMembers(userId int, todoListId int) ([]domain.Assignee, error) {
+	todoList, err := s.listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetListMembers(todoList.Id)
+}
+
+// GetAllByItems returns the assignees of many items at once, items the user
+// has no access to have none.
+func (s *AssigneeService) GetAllByItems(userId int, todoItemIds []int) (map[int][]domain.Assignee, error) {
+	return s.repo.GetAllByItems(userId, todoItemIds)
+}
+
+// GetListMembersByLists returns the members of many lists at once, lists the
+// user has no access to have none.
+func (s *AssigneeService) GetListMembersByLists(userId int, todoListIds []int) (map[int][]domain.Assignee, error) {
+	return s.repo.GetListMembersByLists(userId, todoListIds)
+}
+
+func (s *AssigneeService) Set(userId int, todoItemId int, userIds []int) ([]domain.Assignee, error) {
+	if err := checkItemWritable(s.listRepo, userId, todoItemId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Set(userId, todoItemId, userIds); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAll(todoItemId)
+}
+
+func (s *AssigneeService) GetAssigned(userId int) ([]domain.AssignedTodoItem, error) {
+	return s.repo.GetAssigned(userId)
+}
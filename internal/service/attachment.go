@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/IvanMeln1k/go-todo-app/pkg/blobstore"
+	"github.com/sirupsen/logrus"
+)
+
+const MaxAttachmentSize = 10 << 20
+
+var (
+	ErrAttachmentTooLarge       = domain.NewTooLargeError("attachment_too_large", "Attachment is too large")
+	ErrAttachmentTypeNotAllowed = domain.NewUnsupportedMediaTypeError("attachment_type_not_allowed",
+		"Attachment type is not allowed")
+	ErrAttachmentEmpty = domain.NewValidationError("attachment_empty", "Attachment is empty")
+)
+
+// allowedAttachmentTypes lists media types accepted for upload. The type is
+// sniffed from the content, the one sent by the client is ignored.
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+type AttachmentService struct {
+	repo     repository.Attachment
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+	blobs    repository.BlobStore
+}
+
+func NewAttachmentService(repo repository.Attachment, listRepo repository.TodoList, itemRepo repository.TodoItem,
+	blobs repository.BlobStore) *AttachmentService {
+	return &AttachmentService{
+		repo:     repo,
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+		blobs:    blobs,
+	}
+}
+
+func (s *AttachmentService) Upload(ctx context.Context, userId int, todoItemId int, name string,
+	r io.Reader) (domain.Attachment, error) {
+	attachment := domain.Attachment{
+		Name: attachmentName(name),
+	}
+
+	if err := checkItemWritable(s.listRepo, userId, todoItemId); err != nil {
+		return attachment, err
+	}
+
+	// The upload is spooled to disk first: size, checksum and content type
+	// must be known before anything is written to the blob store.
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return attachment, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, MaxAttachmentSize+1))
+	if err != nil {
+		return attachment, err
+	}
+	if size > MaxAttachmentSize {
+		return attachment, ErrAttachmentTooLarge
+	}
+	if size == 0 {
+		return attachment, ErrAttachmentEmpty
+	}
+
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return attachment, err
+	}
+	contentType := http.DetectContentType(head[:n])
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !allowedAttachmentTypes[mediaType] {
+		return attachment, ErrAttachmentTypeNotAllowed
+	}
+
+	attachment.Size = size
+	attachment.ContentType = contentType
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	attachment.StorageKey, err = newStorageKey(todoItemId)
+	if err != nil {
+		return attachment, err
+	}
+
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return attachment, err
+	}
+	if err = s.blobs.Put(ctx, attachment.StorageKey, tmp, size, contentType); err != nil {
+		return attachment, err
+	}
+
+	attachment.Id, err = s.repo.Create(userId, todoItemId, attachment)
+	if err != nil {
+		if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			logrus.Error(err)
+		}
+		return attachment, err
+	}
+
+	return s.repo.GetById(userId, attachment.Id)
+}
+
+func (s *AttachmentService) GetAll(userId int, todoItemId int) ([]domain.Attachment, error) {
+	todoItem, err := s.itemRepo.GetById(userId, todoItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAll(todoItem.Id)
+}
+
+func (s *AttachmentService) Open(ctx context.Context, userId int, attachmentId int) (domain.Attachment,
+	io.ReadCloser, error) {
+	attachment, err := s.repo.GetById(userId, attachmentId)
+	if err != nil {
+		return attachment, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return attachment, nil, domain.ErrAttachmentNotFound
+		}
+		return attachment, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// Delete removes the attachment record. The blob is queued for removal by the
+// database and deleted later by CleanupBlobs.
+func (s *AttachmentService) Delete(userId int, attachmentId int) error {
+	attachment, err := s.repo.GetById(userId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	if err = checkItemWritable(s.listRepo, userId, attachment.ItemId); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(attachment.Id)
+}
+
+// CleanupBlobs deletes up to limit blobs left behind by deleted attachments
+// and returns how many were removed.
+func (s *AttachmentService) CleanupBlobs(ctx context.Context, limit int) (int, error) {
+	keys, err := s.repo.GetOrphanedBlobs(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if err = s.blobs.Delete(ctx, key); err != nil {
+			return deleted, err
+		}
+		if err = s.repo.DeleteOrphanedBlob(key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func newStorageKey(todoItemId int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("items/%d/%s", todoItemId, hex.EncodeToString(b)), nil
+}
+
+// attachmentName strips directories some clients send along with the file
+// name and keeps the name within the column size.
+func attachmentName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		name = "attachment"
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+	return name
+}
@@ -30,16 +30,15 @@ func NewAuthService(repo repository.Authorization) *AuthService {
 }
 
 var (
-	ErrUsernameAlreadyInUse      = errors.New("username already in use")
-	ErrInvalidUsernameOrPassowrd = errors.New("invalid username or password")
-	ErrUserNotFound              = errors.New("user not found")
+	ErrUsernameAlreadyInUse      = domain.NewConflictError("username_taken", "Username already in use")
+	ErrInvalidUsernameOrPassowrd = domain.NewUnauthorizedError("invalid_credentials", "Invalid username or password")
 	ErrCreateUser                = errors.New("error to create user")
 	ErrGetUser                   = errors.New("error to get user")
 	ErrInternal                  = errors.New("internal error")
-	ErrTokenExpired              = errors.New("token expired")
-	ErrInvalidTokenSignature     = errors.New("invalid token signature")
-	ErrInvalidSession            = errors.New("invalid session")
-	ErrSessionExpiredOrInvalid   = errors.New("session expired or invalid")
+	ErrTokenExpired              = domain.NewUnauthorizedError("token_expired", "Token is expired")
+	ErrInvalidTokenSignature     = domain.NewUnauthorizedError("invalid_token", "Invalid token signature")
+	ErrInvalidSession            = domain.NewUnauthorizedError("invalid_session", "Invalid session")
+	ErrSessionExpiredOrInvalid   = domain.NewUnauthorizedError("session_expired", "Session expired or invalid")
 )
 
 func (s *AuthService) CreateUser(user domain.User) (int, error) {
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+var (
+	ErrNotCommentAuthor = domain.NewForbiddenError("not_comment_author",
+		"Only the author can change the comment")
+)
+
+type CommentService struct {
+	repo     repository.Comment
+	itemRepo repository.TodoItem
+}
+
+func NewCommentService(repo repository.Comment, itemRepo repository.TodoItem) *CommentService {
+	return &CommentService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *CommentService) Create(userId int, todoItemId int, input domain.CommentInput) (domain.Comment, error) {
+	commentId, err := s.repo.Create(userId, todoItemId, input.Body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	return s.repo.GetById(userId, commentId)
+}
+
+func (s *CommentService) GetAll(userId int, todoItemId int, limit int, offset int) (domain.CommentsPage, error) {
+	page := domain.CommentsPage{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	todoItem, err := s.itemRepo.GetById(userId, todoItemId)
+	if err != nil {
+		return page, err
+	}
+
+	page.Comments, page.Total, err = s.repo.GetAll(todoItem.Id, limit, offset)
+	return page, err
+}
+
+func (s *CommentService) getOwnComment(userId int, commentId int) (domain.Comment, error) {
+	comment, err := s.repo.GetById(userId, commentId)
+	if err != nil {
+		return comment, err
+	}
+	if comment.Author.Id != userId {
+		return comment, ErrNotCommentAuthor
+	}
+	return comment, nil
+}
+
+func (s *CommentService) Update(userId int, commentId int, input domain.CommentInput) (domain.Comment, error) {
+	comment, err := s.getOwnComment(userId, commentId)
+	if err != nil {
+		return comment, err
+	}
+
+	if err = s.repo.Update(comment.Id, input.Body); err != nil {
+		return comment, err
+	}
+
+	return s.repo.GetById(userId, comment.Id)
+}
+
+func (s *CommentService) Delete(userId int, commentId int) error {
+	comment, err := s.getOwnComment(userId, commentId)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(comment.Id)
+}
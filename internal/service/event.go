@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// streamBuffer is the number of events a stream holds for a slow client.
+	// A client that falls further behind is disconnected and resumes with
+	// the id of the last event it got.
+	streamBuffer = 64
+	// replayPage is the number of missed activities read at a time for a
+	// resuming client.
+	replayPage = 500
+)
+
+type EventService struct {
+	activityRepo repository.Activity
+	events       repository.Events
+
+	start sync.Once
+	mu    sync.Mutex
+	// streams holds the streams of this instance with the user of each.
+	streams map[chan domain.Activity]int
+}
+
+func NewEventService(activityRepo repository.Activity, events repository.Events) *EventService {
+	return &EventService{
+		activityRepo: activityRepo,
+		events:       events,
+		streams:      map[chan domain.Activity]int{},
+	}
+}
+
+// Subscribe streams the activity on the lists the user can access until ctx
+// is done. With a lastEventId it first replays the activity recorded after
+// it. The channel is also closed when the stream falls behind, the client
+// then resumes from the last activity it got.
+func (s *EventService) Subscribe(ctx context.Context, userId int, lastEventId int) (<-chan domain.Activity, error) {
+	s.start.Do(func() { go s.fanOut() })
+
+	// The stream is added before the missed activity is read, so nothing
+	// recorded in between is lost.
+	stream := make(chan domain.Activity, streamBuffer)
+	s.mu.Lock()
+	s.streams[stream] = userId
+	s.mu.Unlock()
+
+	var missed []domain.Activity
+	if lastEventId > 0 {
+		var err error
+		missed, err = s.activityRepo.GetSince(userId, lastEventId, replayPage)
+		if err != nil {
+			s.remove(stream)
+			return nil, err
+		}
+	}
+
+	activities := make(chan domain.Activity)
+	go func() {
+		defer close(activities)
+		defer s.remove(stream)
+
+		lastId := lastEventId
+		send := func(activity domain.Activity) bool {
+			select {
+			case activities <- activity:
+				lastId = activity.Id
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// The missed activity is replayed page by page until it catches up.
+		// On an error the stream ends and the client resumes from the last
+		// activity it got.
+		for len(missed) > 0 {
+			for _, activity := range missed {
+				if !send(activity) {
+					return
+				}
+			}
+			if len(missed) < replayPage {
+				break
+			}
+
+			var err error
+			missed, err = s.activityRepo.GetSince(userId, lastId, replayPage)
+			if err != nil {
+				logrus.Errorf("error replaying activity: %s", err.Error())
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case activity, ok := <-stream:
+				if !ok {
+					return
+				}
+				if activity.Id <= lastId {
+					continue
+				}
+				if !send(activity) {
+					return
+				}
+			}
+		}
+	}()
+
+	return activities, nil
+}
+
+// fanOut delivers the events of all instances to the streams of this one.
+// When the subscription is lost the streams are closed, as they may have
+// missed events, and their clients resume.
+func (s *EventService) fanOut() {
+	for {
+		events, err := s.events.Subscribe(context.Background())
+		if err != nil {
+			logrus.Errorf("error subscribing to events: %s", err.Error())
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for event := range events {
+			s.deliver(event)
+		}
+		s.closeAll()
+	}
+}
+
+// deliver reads the activity of the event once and passes it to the streams
+// of the users who can access its list.
+func (s *EventService) deliver(event domain.ActivityEvent) {
+	s.mu.Lock()
+	idle := len(s.streams) == 0
+	s.mu.Unlock()
+	if idle {
+		return
+	}
+
+	activity, userIds, err := s.activityRepo.GetWithMembers(event.ActivityId)
+	if err != nil {
+		if errors.Is(err, domain.ErrActivityNotFound) {
+			return
+		}
+		// The streams would miss the activity, their clients resume instead.
+		logrus.Errorf("error getting activity %d: %s", event.ActivityId, err.Error())
+		s.closeAll()
+		return
+	}
+	members := make(map[int]bool, len(userIds))
+	for _, userId := range userIds {
+		members[userId] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for stream, userId := range s.streams {
+		if !members[userId] {
+			continue
+		}
+		select {
+		case stream <- activity:
+		default:
+			delete(s.streams, stream)
+			close(stream)
+		}
+	}
+}
+
+func (s *EventService) remove(stream chan domain.Activity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.streams[stream]; ok {
+		delete(s.streams, stream)
+		close(stream)
+	}
+}
+
+func (s *EventService) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for stream := range s.streams {
+		delete(s.streams, stream)
+		close(stream)
+	}
+}
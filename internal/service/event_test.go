@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEvents struct {
+	events chan domain.ActivityEvent
+}
+
+func (e *fakeEvents) Publish(ctx context.Context, event domain.ActivityEvent) error {
+	e.events <- event
+	return nil
+}
+
+func (e *fakeEvents) Subscribe(ctx context.Context) (<-chan domain.ActivityEvent, error) {
+	return e.events, nil
+}
+
+// fakeActivity holds the activity the user can access and the activity on
+// lists of other users.
+type fakeActivity struct {
+	repository.Activity
+	activities []domain.Activity
+	others     []domain.Activity
+}
+
+func (r *fakeActivity) GetWithMembers(activityId int) (domain.Activity, []int, error) {
+	for _, activity := range r.activities {
+		if activity.Id == activityId {
+			return activity, []int{1}, nil
+		}
+	}
+	for _, activity := range r.others {
+		if activity.Id == activityId {
+			return activity, []int{2}, nil
+		}
+	}
+	return domain.Activity{}, nil, domain.ErrActivityNotFound
+}
+
+func (r *fakeActivity) GetSince(userId int, activityId int, limit int) ([]domain.Activity, error) {
+	var activities []domain.Activity
+	for _, activity := range r.activities {
+		if activity.Id > activityId && len(activities) < limit {
+			activities = append(activities, activity)
+		}
+	}
+	return activities, nil
+}
+
+func receive(t *testing.T, activities <-chan domain.Activity) (domain.Activity, bool) {
+	t.Helper()
+	select {
+	case activity, ok := <-activities:
+		return activity, ok
+	case <-time.After(time.Second):
+		t.Fatal("no activity received")
+		return domain.Activity{}, false
+	}
+}
+
+func TestEventService_Subscribe(t *testing.T) {
+	events := &fakeEvents{events: make(chan domain.ActivityEvent)}
+	activityRepo := &fakeActivity{activities: []domain.Activity{
+		{Id: 3, Action: domain.ActivityListCreated},
+		{Id: 4, Action: domain.ActivityItemCreated},
+		{Id: 6, Action: domain.ActivityItemCompleted},
+	}, others: []domain.Activity{
+		{Id: 5, Action: domain.ActivityItemCreated},
+	}}
+	s := NewEventService(activityRepo, events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	activities, err := s.Subscribe(ctx, 1, 3)
+	assert.NoError(t, err)
+
+	// The missed activity is replayed, an event for it is not sent again and
+	// the activity on lists of other users is skipped.
+	activity, _ := receive(t, activities)
+	assert.Equal(t, 4, activity.Id)
+	for _, activityId := range []int{4, 5, 6} {
+		events.events <- domain.ActivityEvent{ActivityId: activityId}
+	}
+	activity, _ = receive(t, activities)
+	assert.Equal(t, 6, activity.Id)
+
+	cancel()
+	_, ok := receive(t, activities)
+	assert.False(t, ok)
+}
+
+func TestEventService_Subscribe_replayPages(t *testing.T) {
+	events := &fakeEvents{events: make(chan domain.ActivityEvent)}
+	activityRepo := &fakeActivity{}
+	for id := 1; id <= 2*replayPage+1; id++ {
+		activityRepo.activities = append(activityRepo.activities, domain.Activity{Id: id})
+	}
+	s := NewEventService(activityRepo, events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	activities, err := s.Subscribe(ctx, 1, 1)
+	assert.NoError(t, err)
+
+	for id := 2; id <= 2*replayPage+1; id++ {
+		activity, _ := receive(t, activities)
+		assert.Equal(t, id, activity.Id)
+	}
+}
+
+func TestEventService_Subscribe_slowClient(t *testing.T) {
+	events := &fakeEvents{events: make(chan domain.ActivityEvent)}
+	s := NewEventService(&fakeActivity{activities: []domain.Activity{{Id: 1}}}, events)
+
+	activities, err := s.Subscribe(context.Background(), 1, 0)
+	assert.NoError(t, err)
+
+	// The first event is taken by the stream, which then waits for the client
+	// while the buffer fills up and overflows. The fan-out takes an event only
+	// after it delivered the previous one, so the last one makes sure the
+	// overflow happened.
+	for i := 0; i < streamBuffer+3; i++ {
+		events.events <- domain.ActivityEvent{ActivityId: 1}
+	}
+
+	activity, _ := receive(t, activities)
+	assert.Equal(t, 1, activity.Id)
+	_, ok := receive(t, activities)
+	assert.False(t, ok)
+}
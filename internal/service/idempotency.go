@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+const (
+	// IdempotencyKeyTTL is how long a response is replayed for its key.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyLockTTL bounds how long a request that never finished,
+	// e.g. because the server crashed, keeps its key blocked.
+	idempotencyLockTTL = time.Minute
+)
+
+var (
+	ErrIdempotencyKeyReused = domain.NewValidationError("idempotency_key_reused",
+		"Idempotency-Key was used with a different request")
+	ErrIdempotencyKeyInProgress = domain.NewConflictError("idempotency_key_in_progress",
+		"Request with this Idempotency-Key is in progress")
+)
+
+type IdempotencyService struct {
+	repo repository.Idempotency
+}
+
+func NewIdempotencyService(repo repository.Idempotency) *IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+	}
+}
+
+// Begin reserves the key for the request identified by fingerprint. It
+// returns the stored response when the request has already been processed
+// and nil when the caller should process it and then call Complete or Abort.
+func (s *IdempotencyService) Begin(ctx context.Context, userId int, key string,
+	fingerprint string) (*domain.IdempotentResponse, error) {
+	response, reserved, err := s.repo.Reserve(ctx, userId, key, fingerprint, idempotencyLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	if response.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if !response.Completed() {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return &response, nil
+}
+
+func (s *IdempotencyService) Complete(ctx context.Context, userId int, key string,
+	response domain.IdempotentResponse) error {
+	return s.repo.Save(ctx, userId, key, response, IdempotencyKeyTTL)
+}
+
+// Abort releases the key so that the request can be retried.
+func (s *IdempotencyService) Abort(ctx context.Context, userId int, key string) error {
+	return s.repo.Delete(ctx, userId, key)
+}
@@ -17,13 +17,21 @@ func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList)
 	}
 }
 
+func (s *TodoItemService) getWritableList(userId int, todoListId int) (domain.TodoList, error) {
+	return getWritableList(s.listRepo, userId, todoListId)
+}
+
+func (s *TodoItemService) getItemWritableList(userId int, todoItemId int) (domain.TodoList, error) {
+	return getItemWritableList(s.listRepo, userId, todoItemId)
+}
+
 func (s *TodoItemService) Create(userId int, todoListId int, todoItem domain.TodoItem) (int, error) {
-	todoList, err := s.listRepo.GetById(userId, todoListId)
+	todoList, err := s.getWritableList(userId, todoListId)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.repo.Create(todoList.Id, todoItem)
+	return s.repo.Create(userId, todoList.Id, todoItem)
 }
 
 func (s *TodoItemService) GetAll(userId int, todoListId int) ([]domain.TodoItem, error) {
@@ -35,14 +43,58 @@ func (s *TodoItemService) GetAll(userId int, todoListId int) ([]domain.TodoItem,
 	return s.repo.GetAll(todoList.Id)
 }
 
+// GetAllByLists returns the items of many lists at once, lists the user has
+// no access to have none.
+func (s *TodoItemService) GetAllByLists(userId int, todoListIds []int) (map[int][]domain.TodoItem, error) {
+	return s.repo.GetAllByLists(userId, todoListIds)
+}
+
 func (s *TodoItemService) GetById(userId int, todoItemId int) (domain.TodoItem, error) {
 	return s.repo.GetById(userId, todoItemId)
 }
 
-func (s *TodoItemService) Delete(userId int, todoItemId int) error {
-	return s.repo.Delete(userId, todoItemId)
+func (s *TodoItemService) Delete(userId int, todoItemId int, version *int) error {
+	if _, err := s.getItemWritableList(userId, todoItemId); err != nil {
+		return err
+	}
+
+	return versionError(s.repo.Delete(userId, todoItemId, version))
+}
+
+func (s *TodoItemService) Replace(userId int, todoItemId int, replaceTodoItem domain.ReplaceTodoItem) (domain.TodoItem, error) {
+	if _, err := s.getItemWritableList(userId, todoItemId); err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	todoItem, err := s.repo.Replace(userId, todoItemId, replaceTodoItem)
+	return todoItem, versionError(err)
+}
+
+func (s *TodoItemService) Move(userId int, todoItemId int, todoListId int) (domain.TodoItem, error) {
+	if _, err := s.getItemWritableList(userId, todoItemId); err != nil {
+		return domain.TodoItem{}, err
+	}
+	if _, err := s.getWritableList(userId, todoListId); err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	return s.repo.Move(userId, todoItemId, todoListId)
+}
+
+func (s *TodoItemService) Copy(userId int, todoItemId int, todoListId int) (int, error) {
+	todoList, err := s.getWritableList(userId, todoListId)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.Copy(userId, todoItemId, todoList.Id)
 }
 
-func (s *TodoItemService) Update(userId int, todoItemId int, updateTodoItem domain.UpdateTodoItem) (domain.TodoItem, error) {
-	return s.repo.Update(userId, todoItemId, updateTodoItem)
+func (s *TodoItemService) Bulk(userId int, todoListId int, operations []domain.BulkItemOperation) ([]domain.BulkItemResult, error) {
+	todoList, err := s.getWritableList(userId, todoListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Bulk(userId, todoList.Id, operations)
 }
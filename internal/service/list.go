@@ -1,10 +1,16 @@
 package service
 
 import (
+	"errors"
+
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
 	"github.com/IvanMeln1k/go-todo-app/internal/repository"
 )
 
+var (
+	ErrVersionMismatch = domain.NewPreconditionFailedError("version_mismatch", "Record has changed since it was read")
+)
+
 type TodoListService struct {
 	repo repository.TodoList
 }
@@ -15,22 +21,89 @@ func NewTodoListService(repo repository.TodoList) *TodoListService {
 	}
 }
 
+// getWritableList returns the list if the user can access it and it is not
+// archived.
+func getWritableList(listRepo repository.TodoList, userId int, todoListId int) (domain.TodoList, error) {
+	todoList, err := listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return todoList, err
+	}
+	if todoList.Archived {
+		return todoList, domain.ErrListArchived
+	}
+	return todoList, nil
+}
+
+// checkItemWritable reports whether the user can change the item, that is the
+// item's list is accessible and not archived.
+func checkItemWritable(listRepo repository.TodoList, userId int, todoItemId int) error {
+	_, err := getItemWritableList(listRepo, userId, todoItemId)
+	return err
+}
+
+// getItemWritableList returns the list of the item if the user can change
+// the item.
+func getItemWritableList(listRepo repository.TodoList, userId int, todoItemId int) (domain.TodoList, error) {
+	todoList, err := listRepo.GetByItemId(userId, todoItemId)
+	if err != nil {
+		return todoList, err
+	}
+	if todoList.Archived {
+		return todoList, domain.ErrListArchived
+	}
+	return todoList, nil
+}
+
 func (s *TodoListService) Create(userId int, todoList domain.TodoList) (int, error) {
 	return s.repo.Create(userId, todoList)
 }
 
-func (s *TodoListService) GetAll(userId int) ([]domain.TodoList, error) {
-	return s.repo.GetAll(userId)
+func (s *TodoListService) GetAll(userId int, archived bool) ([]domain.TodoList, error) {
+	return s.repo.GetAll(userId, archived)
 }
 
 func (s *TodoListService) GetById(userId int, todoListId int) (domain.TodoList, error) {
 	return s.repo.GetById(userId, todoListId)
 }
 
-func (s *TodoListService) Delete(userId int, todoListId int) error {
-	return s.repo.Delete(userId, todoListId)
+// versionError maps a failed version precondition of the repository to the
+// service error.
+func versionError(err error) error {
+	if errors.Is(err, repository.ErrVersionMismatch) {
+		return ErrVersionMismatch
+	}
+	return err
+}
+
+func (s *TodoListService) Delete(userId int, todoListId int, version *int) error {
+	return versionError(s.repo.Delete(userId, todoListId, version))
+}
+
+func (s *TodoListService) Replace(userId int, todoListId int, replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error) {
+	if _, err := getWritableList(s.repo, userId, todoListId); err != nil {
+		return domain.TodoList{}, err
+	}
+
+	todoList, err := s.repo.Replace(userId, todoListId, replaceTodoList)
+	return todoList, versionError(err)
+}
+
+func (s *TodoListService) Archive(userId int, todoListId int) (domain.TodoList, error) {
+	return s.repo.SetArchived(userId, todoListId, true)
+}
+
+func (s *TodoListService) Unarchive(userId int, todoListId int) (domain.TodoList, error) {
+	return s.repo.SetArchived(userId, todoListId, false)
+}
+
+func (s *TodoListService) Pin(userId int, todoListId int) (domain.TodoList, error) {
+	return s.repo.SetPinned(userId, todoListId, true)
+}
+
+func (s *TodoListService) Unpin(userId int, todoListId int) (domain.TodoList, error) {
+	return s.repo.SetPinned(userId, todoListId, false)
 }
 
-func (s *TodoListService) Update(userId int, todoListId int, updateTodoList domain.UpdateTodoList) (domain.TodoList, error) {
-	return s.repo.Update(userId, todoListId, updateTodoList)
+func (s *TodoListService) Duplicate(userId int, todoListId int, duplicate domain.DuplicateTodoList) (int, error) {
+	return s.repo.Duplicate(userId, todoListId, duplicate)
 }
@@ -6,7 +6,9 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/IvanMeln1k/go-todo-app/internal/domain"
 	service "github.com/IvanMeln1k/go-todo-app/internal/service"
@@ -147,6 +149,21 @@ func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockTodoList) Archive(userId, todoListId int) (domain.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", userId, todoListId)
+	ret0, _ := ret[0].(domain.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockTodoListMockRecorder) Archive(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTodoList)(nil).Archive), userId, todoListId)
+}
+
 // Create mocks base method.
 func (m *MockTodoList) Create(userId int, todoList domain.TodoList) (int, error) {
 	m.ctrl.T.Helper()
@@ -163,32 +180,47 @@ func (mr *MockTodoListMockRecorder) Create(userId, todoList interface{}) *gomock
 }
 
 // Delete mocks base method.
-func (m *MockTodoList) Delete(userId, todoListId int) error {
+func (m *MockTodoList) Delete(userId, todoListId int, version *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, todoListId)
+	ret := m.ctrl.Call(m, "Delete", userId, todoListId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoListMockRecorder) Delete(userId, todoListId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) Delete(userId, todoListId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, todoListId, version)
+}
+
+// Duplicate mocks base method.
+func (m *MockTodoList) Duplicate(userId, todoListId int, duplicate domain.DuplicateTodoList) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", userId, todoListId, duplicate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockTodoListMockRecorder) Duplicate(userId, todoListId, duplicate interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, todoListId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), userId, todoListId, duplicate)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoList) GetAll(userId int) ([]domain.TodoList, error) {
+func (m *MockTodoList) GetAll(userId int, archived bool) ([]domain.TodoList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret := m.ctrl.Call(m, "GetAll", userId, archived)
 	ret0, _ := ret[0].([]domain.TodoList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) GetAll(userId, archived interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId, archived)
 }
 
 // GetById mocks base method.
@@ -206,19 +238,64 @@ func (mr *MockTodoListMockRecorder) GetById(userId, todoListId interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), userId, todoListId)
 }
 
-// Update mocks base method.
-func (m *MockTodoList) Update(userId, todoListId int, updateTodoList domain.UpdateTodoList) (domain.TodoList, error) {
+// Pin mocks base method.
+func (m *MockTodoList) Pin(userId, todoListId int) (domain.TodoList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, todoListId, updateTodoList)
+	ret := m.ctrl.Call(m, "Pin", userId, todoListId)
 	ret0, _ := ret[0].(domain.TodoList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockTodoListMockRecorder) Update(userId, todoListId, updateTodoList interface{}) *gomock.Call {
+// Pin indicates an expected call of Pin.
+func (mr *MockTodoListMockRecorder) Pin(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockTodoList)(nil).Pin), userId, todoListId)
+}
+
+// Replace mocks base method.
+func (m *MockTodoList) Replace(userId, todoListId int, replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", userId, todoListId, replaceTodoList)
+	ret0, _ := ret[0].(domain.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoListMockRecorder) Replace(userId, todoListId, replaceTodoList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoList)(nil).Replace), userId, todoListId, replaceTodoList)
+}
+
+// Unarchive mocks base method.
+func (m *MockTodoList) Unarchive(userId, todoListId int) (domain.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", userId, todoListId)
+	ret0, _ := ret[0].(domain.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockTodoListMockRecorder) Unarchive(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTodoList)(nil).Unarchive), userId, todoListId)
+}
+
+// Unpin mocks base method.
+func (m *MockTodoList) Unpin(userId, todoListId int) (domain.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", userId, todoListId)
+	ret0, _ := ret[0].(domain.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockTodoListMockRecorder) Unpin(userId, todoListId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, todoListId, updateTodoList)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockTodoList)(nil).Unpin), userId, todoListId)
 }
 
 // MockTodoItem is a mock of TodoItem interface.
@@ -244,6 +321,36 @@ func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockTodoItem) Bulk(userId, todoListId int, operations []domain.BulkItemOperation) ([]domain.BulkItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", userId, todoListId, operations)
+	ret0, _ := ret[0].([]domain.BulkItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTodoItemMockRecorder) Bulk(userId, todoListId, operations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTodoItem)(nil).Bulk), userId, todoListId, operations)
+}
+
+// Copy mocks base method.
+func (m *MockTodoItem) Copy(userId, todoItemId, todoListId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", userId, todoItemId, todoListId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockTodoItemMockRecorder) Copy(userId, todoItemId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), userId, todoItemId, todoListId)
+}
+
 // Create mocks base method.
 func (m *MockTodoItem) Create(userId, todoListId int, todoItem domain.TodoItem) (int, error) {
 	m.ctrl.T.Helper()
@@ -260,17 +367,17 @@ func (mr *MockTodoItemMockRecorder) Create(userId, todoListId, todoItem interfac
 }
 
 // Delete mocks base method.
-func (m *MockTodoItem) Delete(userId, todoItemId int) error {
+func (m *MockTodoItem) Delete(userId, todoItemId int, version *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, todoItemId)
+	ret := m.ctrl.Call(m, "Delete", userId, todoItemId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoItemMockRecorder) Delete(userId, todoItemId interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) Delete(userId, todoItemId, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, todoItemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, todoItemId, version)
 }
 
 // GetAll mocks base method.
@@ -288,6 +395,21 @@ func (mr *MockTodoItemMockRecorder) GetAll(userId, todoListId interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, todoListId)
 }
 
+// GetAllByLists mocks base method.
+func (m *MockTodoItem) GetAllByLists(userId int, todoListIds []int) (map[int][]domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByLists", userId, todoListIds)
+	ret0, _ := ret[0].(map[int][]domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByLists indicates an expected call of GetAllByLists.
+func (mr *MockTodoItemMockRecorder) GetAllByLists(userId, todoListIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByLists", reflect.TypeOf((*MockTodoItem)(nil).GetAllByLists), userId, todoListIds)
+}
+
 // GetById mocks base method.
 func (m *MockTodoItem) GetById(userId, todoItemId int) (domain.TodoItem, error) {
 	m.ctrl.T.Helper()
@@ -303,17 +425,1087 @@ func (mr *MockTodoItemMockRecorder) GetById(userId, todoItemId interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, todoItemId)
 }
 
-// Update mocks base method.
-func (m *MockTodoItem) Update(userId, todoItemId int, updateTodoItem domain.UpdateTodoItem) (domain.TodoItem, error) {
+// Move mocks base method.
+func (m *MockTodoItem) Move(userId, todoItemId, todoListId int) (domain.TodoItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, todoItemId, updateTodoItem)
+	ret := m.ctrl.Call(m, "Move", userId, todoItemId, todoListId)
 	ret0, _ := ret[0].(domain.TodoItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockTodoItemMockRecorder) Update(userId, todoItemId, updateTodoItem interface{}) *gomock.Call {
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(userId, todoItemId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), userId, todoItemId, todoListId)
+}
+
+// Replace mocks base method.
+func (m *MockTodoItem) Replace(userId, todoItemId int, replaceTodoItem domain.ReplaceTodoItem) (domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", userId, todoItemId, replaceTodoItem)
+	ret0, _ := ret[0].(domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoItemMockRecorder) Replace(userId, todoItemId, replaceTodoItem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoItem)(nil).Replace), userId, todoItemId, replaceTodoItem)
+}
+
+// MockTrash is a mock of Trash interface.
+type MockTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashMockRecorder
+}
+
+// MockTrashMockRecorder is the mock recorder for MockTrash.
+type MockTrashMockRecorder struct {
+	mock *MockTrash
+}
+
+// NewMockTrash creates a new mock instance.
+func NewMockTrash(ctrl *gomock.Controller) *MockTrash {
+	mock := &MockTrash{ctrl: ctrl}
+	mock.recorder = &MockTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrash) EXPECT() *MockTrashMockRecorder {
+	return m.recorder
+}
+
+// DeleteItem mocks base method.
+func (m *MockTrash) DeleteItem(userId, todoItemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", userId, todoItemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockTrashMockRecorder) DeleteItem(userId, todoItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockTrash)(nil).DeleteItem), userId, todoItemId)
+}
+
+// DeleteList mocks base method.
+func (m *MockTrash) DeleteList(userId, todoListId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteList", userId, todoListId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteList indicates an expected call of DeleteList.
+func (mr *MockTrashMockRecorder) DeleteList(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockTrash)(nil).DeleteList), userId, todoListId)
+}
+
+// GetAll mocks base method.
+func (m *MockTrash) GetAll(userId int) (domain.Trash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].(domain.Trash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTrashMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTrash)(nil).GetAll), userId)
+}
+
+// Purge mocks base method.
+func (m *MockTrash) Purge(retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashMockRecorder) Purge(retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrash)(nil).Purge), retention)
+}
+
+// RestoreItem mocks base method.
+func (m *MockTrash) RestoreItem(userId, todoItemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItem", userId, todoItemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreItem indicates an expected call of RestoreItem.
+func (mr *MockTrashMockRecorder) RestoreItem(userId, todoItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItem", reflect.TypeOf((*MockTrash)(nil).RestoreItem), userId, todoItemId)
+}
+
+// RestoreList mocks base method.
+func (m *MockTrash) RestoreList(userId, todoListId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", userId, todoListId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockTrashMockRecorder) RestoreList(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockTrash)(nil).RestoreList), userId, todoListId)
+}
+
+// MockTemplate is a mock of Template interface.
+type MockTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateMockRecorder
+}
+
+// MockTemplateMockRecorder is the mock recorder for MockTemplate.
+type MockTemplateMockRecorder struct {
+	mock *MockTemplate
+}
+
+// NewMockTemplate creates a new mock instance.
+func NewMockTemplate(ctrl *gomock.Controller) *MockTemplate {
+	mock := &MockTemplate{ctrl: ctrl}
+	mock.recorder = &MockTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplate) EXPECT() *MockTemplateMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTemplate) Create(userId, todoListId int, createTemplate domain.CreateListTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, todoListId, createTemplate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTemplateMockRecorder) Create(userId, todoListId, createTemplate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTemplate)(nil).Create), userId, todoListId, createTemplate)
+}
+
+// Delete mocks base method.
+func (m *MockTemplate) Delete(userId, templateId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, templateId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTemplateMockRecorder) Delete(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTemplate)(nil).Delete), userId, templateId)
+}
+
+// GetAll mocks base method.
+func (m *MockTemplate) GetAll(userId int) ([]domain.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]domain.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTemplateMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTemplate)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockTemplate) GetById(userId, templateId int) (domain.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, templateId)
+	ret0, _ := ret[0].(domain.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTemplateMockRecorder) GetById(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTemplate)(nil).GetById), userId, templateId)
+}
+
+// Instantiate mocks base method.
+func (m *MockTemplate) Instantiate(userId, templateId int, instantiate domain.InstantiateListTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", userId, templateId, instantiate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockTemplateMockRecorder) Instantiate(userId, templateId, instantiate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockTemplate)(nil).Instantiate), userId, templateId, instantiate)
+}
+
+// MockStatus is a mock of Status interface.
+type MockStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusMockRecorder
+}
+
+// MockStatusMockRecorder is the mock recorder for MockStatus.
+type MockStatusMockRecorder struct {
+	mock *MockStatus
+}
+
+// NewMockStatus creates a new mock instance.
+func NewMockStatus(ctrl *gomock.Controller) *MockStatus {
+	mock := &MockStatus{ctrl: ctrl}
+	mock.recorder = &MockStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatus) EXPECT() *MockStatusMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatus) Create(userId, todoListId int, status domain.Status) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, todoListId, status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusMockRecorder) Create(userId, todoListId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatus)(nil).Create), userId, todoListId, status)
+}
+
+// Delete mocks base method.
+func (m *MockStatus) Delete(userId, statusId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, statusId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStatusMockRecorder) Delete(userId, statusId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStatus)(nil).Delete), userId, statusId)
+}
+
+// GetAll mocks base method.
+func (m *MockStatus) GetAll(userId, todoListId int) ([]domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, todoListId)
+	ret0, _ := ret[0].([]domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockStatusMockRecorder) GetAll(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStatus)(nil).GetAll), userId, todoListId)
+}
+
+// GetBoard mocks base method.
+func (m *MockStatus) GetBoard(userId, todoListId int) (domain.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", userId, todoListId)
+	ret0, _ := ret[0].(domain.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockStatusMockRecorder) GetBoard(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStatus)(nil).GetBoard), userId, todoListId)
+}
+
+// SetItemStatus mocks base method.
+func (m *MockStatus) SetItemStatus(userId, todoItemId, statusId int) (domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemStatus", userId, todoItemId, statusId)
+	ret0, _ := ret[0].(domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetItemStatus indicates an expected call of SetItemStatus.
+func (mr *MockStatusMockRecorder) SetItemStatus(userId, todoItemId, statusId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemStatus", reflect.TypeOf((*MockStatus)(nil).SetItemStatus), userId, todoItemId, statusId)
+}
+
+// Update mocks base method.
+func (m *MockStatus) Update(userId, statusId int, updateStatus domain.UpdateStatus) (domain.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, statusId, updateStatus)
+	ret0, _ := ret[0].(domain.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusMockRecorder) Update(userId, statusId, updateStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatus)(nil).Update), userId, statusId, updateStatus)
+}
+
+// MockAssignee is a mock of Assignee interface.
+type MockAssignee struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssigneeMockRecorder
+}
+
+// MockAssigneeMockRecorder is the mock recorder for MockAssignee.
+type MockAssigneeMockRecorder struct {
+	mock *MockAssignee
+}
+
+// NewMockAssignee creates a new mock instance.
+func NewMockAssignee(ctrl *gomock.Controller) *MockAssignee {
+	mock := &MockAssignee{ctrl: ctrl}
+	mock.recorder = &MockAssigneeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssignee) EXPECT() *MockAssigneeMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockAssignee) GetAll(userId, todoItemId int) ([]domain.Assignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, todoItemId)
+	ret0, _ := ret[0].([]domain.Assignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAssigneeMockRecorder) GetAll(userId, todoItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAssignee)(nil).GetAll), userId, todoItemId)
+}
+
+// GetAllByItems mocks base method.
+func (m *MockAssignee) GetAllByItems(userId int, todoItemIds []int) (map[int][]domain.Assignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByItems", userId, todoItemIds)
+	ret0, _ := ret[0].(map[int][]domain.Assignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByItems indicates an expected call of GetAllByItems.
+func (mr *MockAssigneeMockRecorder) GetAllByItems(userId, todoItemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByItems", reflect.TypeOf((*MockAssignee)(nil).GetAllByItems), userId, todoItemIds)
+}
+
+// GetAssigned mocks base method.
+func (m *MockAssignee) GetAssigned(userId int) ([]domain.AssignedTodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssigned", userId)
+	ret0, _ := ret[0].([]domain.AssignedTodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssigned indicates an expected call of GetAssigned.
+func (mr *MockAssigneeMockRecorder) GetAssigned(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssigned", reflect.TypeOf((*MockAssignee)(nil).GetAssigned), userId)
+}
+
+// GetListMembers mocks base method.
+func (m *MockAssignee) GetListMembers(userId, todoListId int) ([]domain.Assignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMembers", userId, todoListId)
+	ret0, _ := ret[0].([]domain.Assignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMembers indicates an expected call of GetListMembers.
+func (mr *MockAssigneeMockRecorder) GetListMembers(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembers", reflect.TypeOf((*MockAssignee)(nil).GetListMembers), userId, todoListId)
+}
+
+// GetListMembersByLists mocks base method.
+func (m *MockAssignee) GetListMembersByLists(userId int, todoListIds []int) (map[int][]domain.Assignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMembersByLists", userId, todoListIds)
+	ret0, _ := ret[0].(map[int][]domain.Assignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMembersByLists indicates an expected call of GetListMembersByLists.
+func (mr *MockAssigneeMockRecorder) GetListMembersByLists(userId, todoListIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembersByLists", reflect.TypeOf((*MockAssignee)(nil).GetListMembersByLists), userId, todoListIds)
+}
+
+// Set mocks base method.
+func (m *MockAssignee) Set(userId, todoItemId int, userIds []int) ([]domain.Assignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", userId, todoItemId, userIds)
+	ret0, _ := ret[0].([]domain.Assignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockAssigneeMockRecorder) Set(userId, todoItemId, userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockAssignee)(nil).Set), userId, todoItemId, userIds)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockComment) Create(userId, todoItemId int, input domain.CommentInput) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, todoItemId, input)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentMockRecorder) Create(userId, todoItemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComment)(nil).Create), userId, todoItemId, input)
+}
+
+// Delete mocks base method.
+func (m *MockComment) Delete(userId, commentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentMockRecorder) Delete(userId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComment)(nil).Delete), userId, commentId)
+}
+
+// GetAll mocks base method.
+func (m *MockComment) GetAll(userId, todoItemId, limit, offset int) (domain.CommentsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, todoItemId, limit, offset)
+	ret0, _ := ret[0].(domain.CommentsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCommentMockRecorder) GetAll(userId, todoItemId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockComment)(nil).GetAll), userId, todoItemId, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockComment) Update(userId, commentId int, input domain.CommentInput) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, commentId, input)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentMockRecorder) Update(userId, commentId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockComment)(nil).Update), userId, commentId, input)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// CleanupBlobs mocks base method.
+func (m *MockAttachment) CleanupBlobs(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupBlobs", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupBlobs indicates an expected call of CleanupBlobs.
+func (mr *MockAttachmentMockRecorder) CleanupBlobs(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupBlobs", reflect.TypeOf((*MockAttachment)(nil).CleanupBlobs), ctx, limit)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(userId, attachmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, attachmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(userId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), userId, attachmentId)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachment) GetAll(userId, todoItemId int) ([]domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, todoItemId)
+	ret0, _ := ret[0].([]domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentMockRecorder) GetAll(userId, todoItemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachment)(nil).GetAll), userId, todoItemId)
+}
+
+// Open mocks base method.
+func (m *MockAttachment) Open(ctx context.Context, userId, attachmentId int) (domain.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, userId, attachmentId)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentMockRecorder) Open(ctx, userId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachment)(nil).Open), ctx, userId, attachmentId)
+}
+
+// Upload mocks base method.
+func (m *MockAttachment) Upload(ctx context.Context, userId, todoItemId int, name string, r io.Reader) (domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, userId, todoItemId, name, r)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentMockRecorder) Upload(ctx, userId, todoItemId, name, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachment)(nil).Upload), ctx, userId, todoItemId, name, r)
+}
+
+// MockActivity is a mock of Activity interface.
+type MockActivity struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityMockRecorder
+}
+
+// MockActivityMockRecorder is the mock recorder for MockActivity.
+type MockActivityMockRecorder struct {
+	mock *MockActivity
+}
+
+// NewMockActivity creates a new mock instance.
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
+	return m.recorder
+}
+
+// GetByItem mocks base method.
+func (m *MockActivity) GetByItem(userId, todoItemId, limit, offset int) (domain.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItem", userId, todoItemId, limit, offset)
+	ret0, _ := ret[0].(domain.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItem indicates an expected call of GetByItem.
+func (mr *MockActivityMockRecorder) GetByItem(userId, todoItemId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItem", reflect.TypeOf((*MockActivity)(nil).GetByItem), userId, todoItemId, limit, offset)
+}
+
+// GetByList mocks base method.
+func (m *MockActivity) GetByList(userId, todoListId, limit, offset int) (domain.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByList", userId, todoListId, limit, offset)
+	ret0, _ := ret[0].(domain.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByList indicates an expected call of GetByList.
+func (mr *MockActivityMockRecorder) GetByList(userId, todoListId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByList", reflect.TypeOf((*MockActivity)(nil).GetByList), userId, todoListId, limit, offset)
+}
+
+// MockEvents is a mock of Events interface.
+type MockEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsMockRecorder
+}
+
+// MockEventsMockRecorder is the mock recorder for MockEvents.
+type MockEventsMockRecorder struct {
+	mock *MockEvents
+}
+
+// NewMockEvents creates a new mock instance.
+func NewMockEvents(ctrl *gomock.Controller) *MockEvents {
+	mock := &MockEvents{ctrl: ctrl}
+	mock.recorder = &MockEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvents) EXPECT() *MockEventsMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockEvents) Subscribe(ctx context.Context, userId, lastEventId int) (<-chan domain.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userId, lastEventId)
+	ret0, _ := ret[0].(<-chan domain.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventsMockRecorder) Subscribe(ctx, userId, lastEventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEvents)(nil).Subscribe), ctx, userId, lastEventId)
+}
+
+// MockUndo is a mock of Undo interface.
+type MockUndo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoMockRecorder
+}
+
+// MockUndoMockRecorder is the mock recorder for MockUndo.
+type MockUndoMockRecorder struct {
+	mock *MockUndo
+}
+
+// NewMockUndo creates a new mock instance.
+func NewMockUndo(ctrl *gomock.Controller) *MockUndo {
+	mock := &MockUndo{ctrl: ctrl}
+	mock.recorder = &MockUndoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndo) EXPECT() *MockUndoMockRecorder {
+	return m.recorder
+}
+
+// UndoActivity mocks base method.
+func (m *MockUndo) UndoActivity(userId, activityId int) (domain.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoActivity", userId, activityId)
+	ret0, _ := ret[0].(domain.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoActivity indicates an expected call of UndoActivity.
+func (mr *MockUndoMockRecorder) UndoActivity(userId, activityId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoActivity", reflect.TypeOf((*MockUndo)(nil).UndoActivity), userId, activityId)
+}
+
+// UndoLast mocks base method.
+func (m *MockUndo) UndoLast(userId int) (domain.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoLast", userId)
+	ret0, _ := ret[0].(domain.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoLast indicates an expected call of UndoLast.
+func (mr *MockUndoMockRecorder) UndoLast(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoLast", reflect.TypeOf((*MockUndo)(nil).UndoLast), userId)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(userId, todoListId int, webhook domain.Webhook) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, todoListId, webhook)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(userId, todoListId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), userId, todoListId, webhook)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(userId, webhookId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), userId, webhookId)
+}
+
+// Deliver mocks base method.
+func (m *MockWebhook) Deliver(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookMockRecorder) Deliver(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhook)(nil).Deliver), ctx, limit)
+}
+
+// GetAll mocks base method.
+func (m *MockWebhook) GetAll(userId, todoListId int) ([]domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, todoListId)
+	ret0, _ := ret[0].([]domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookMockRecorder) GetAll(userId, todoListId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhook)(nil).GetAll), userId, todoListId)
+}
+
+// GetById mocks base method.
+func (m *MockWebhook) GetById(userId, webhookId int) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, webhookId)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWebhookMockRecorder) GetById(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhook)(nil).GetById), userId, webhookId)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(userId, webhookId, limit, offset int) (domain.WebhookDeliveryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", userId, webhookId, limit, offset)
+	ret0, _ := ret[0].(domain.WebhookDeliveryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(userId, webhookId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), userId, webhookId, limit, offset)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(userId, deliveryId int) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", userId, deliveryId)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(userId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), userId, deliveryId)
+}
+
+// Update mocks base method.
+func (m *MockWebhook) Update(userId, webhookId int, input domain.UpdateWebhook) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, webhookId, input)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookMockRecorder) Update(userId, webhookId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhook)(nil).Update), userId, webhookId, input)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockOutbox) Relay(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxMockRecorder) Relay(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutbox)(nil).Relay), ctx, limit)
+}
+
+// MockSync is a mock of Sync interface.
+type MockSync struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncMockRecorder
+}
+
+// MockSyncMockRecorder is the mock recorder for MockSync.
+type MockSyncMockRecorder struct {
+	mock *MockSync
+}
+
+// NewMockSync creates a new mock instance.
+func NewMockSync(ctrl *gomock.Controller) *MockSync {
+	mock := &MockSync{ctrl: ctrl}
+	mock.recorder = &MockSyncMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSync) EXPECT() *MockSyncMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockSync) Apply(userId int, request domain.SyncRequest) ([]domain.SyncResult, domain.SyncChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", userId, request)
+	ret0, _ := ret[0].([]domain.SyncResult)
+	ret1, _ := ret[1].(domain.SyncChanges)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockSyncMockRecorder) Apply(userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockSync)(nil).Apply), userId, request)
+}
+
+// GetChanges mocks base method.
+func (m *MockSync) GetChanges(userId int, since string) (domain.SyncChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", userId, since)
+	ret0, _ := ret[0].(domain.SyncChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockSyncMockRecorder) GetChanges(userId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockSync)(nil).GetChanges), userId, since)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockIdempotency) Abort(ctx context.Context, userId int, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort", ctx, userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockIdempotencyMockRecorder) Abort(ctx, userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockIdempotency)(nil).Abort), ctx, userId, key)
+}
+
+// Begin mocks base method.
+func (m *MockIdempotency) Begin(ctx context.Context, userId int, key, fingerprint string) (*domain.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, userId, key, fingerprint)
+	ret0, _ := ret[0].(*domain.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyMockRecorder) Begin(ctx, userId, key, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotency)(nil).Begin), ctx, userId, key, fingerprint)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotency) Complete(ctx context.Context, userId int, key string, response domain.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, userId, key, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyMockRecorder) Complete(ctx, userId, key, response interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, todoItemId, updateTodoItem)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), ctx, userId, key, response)
 }
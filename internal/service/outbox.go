@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/IvanMeln1k/go-todo-app/pkg/eventbus"
+)
+
+type OutboxService struct {
+	repo   repository.Outbox
+	bus    repository.EventBus
+	events repository.Events
+}
+
+func NewOutboxService(repo repository.Outbox, bus repository.EventBus, events repository.Events) *OutboxService {
+	return &OutboxService{
+		repo:   repo,
+		bus:    bus,
+		events: events,
+	}
+}
+
+// Relay publishes up to limit events of the outbox to the event bus and
+// returns how many were published. An event is removed from the outbox only
+// once the bus accepted it, so it is published at least once. New entries of
+// the activity log are announced to the event streams instead.
+func (s *OutboxService) Relay(ctx context.Context, limit int) (int, error) {
+	return s.repo.Relay(limit, func(event domain.OutboxEvent) error {
+		if event.AggregateType == domain.AggregateActivity {
+			return s.announce(ctx, event)
+		}
+		return s.bus.Publish(ctx, eventMessage(event))
+	})
+}
+
+// announce publishes a new activity to the streams of all instances. A
+// repeated event only repeats the announcement, streams skip activity they
+// have sent.
+func (s *OutboxService) announce(ctx context.Context, event domain.OutboxEvent) error {
+	var activityEvent domain.ActivityEvent
+	if err := json.Unmarshal(event.Payload, &activityEvent); err != nil {
+		return err
+	}
+
+	return s.events.Publish(ctx, activityEvent)
+}
+
+// eventMessage keys a message by its aggregate, which keeps the events of
+// an aggregate in order on the bus.
+func eventMessage(event domain.OutboxEvent) eventbus.Message {
+	return eventbus.Message{
+		Id:      strconv.Itoa(event.Id),
+		Type:    event.Type,
+		Key:     fmt.Sprintf("%s:%d", event.AggregateType, event.AggregateId),
+		Payload: event.Payload,
+	}
+}
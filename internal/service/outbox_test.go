@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOutbox relays its events the way the repository does: in order,
+// keeping the ones from the first failure on.
+type fakeOutbox struct {
+	events []domain.OutboxEvent
+}
+
+func (r *fakeOutbox) Relay(limit int, publish func(event domain.OutboxEvent) error) (int, error) {
+	published := 0
+	for _, event := range r.events {
+		if published == limit {
+			break
+		}
+		if err := publish(event); err != nil {
+			r.events = r.events[published:]
+			return published, err
+		}
+		published++
+	}
+	r.events = r.events[published:]
+	return published, nil
+}
+
+type fakeBus struct {
+	messages []eventbus.Message
+	fail     bool
+}
+
+func (b *fakeBus) Publish(ctx context.Context, message eventbus.Message) error {
+	if b.fail {
+		return errors.New("bus unavailable")
+	}
+	b.messages = append(b.messages, message)
+	return nil
+}
+
+func (b *fakeBus) Subscribe(ctx context.Context, group string, handler eventbus.Handler) error {
+	return nil
+}
+
+func TestOutboxService_Relay(t *testing.T) {
+	repo := &fakeOutbox{events: []domain.OutboxEvent{
+		{Id: 1, AggregateType: domain.AggregateList, AggregateId: 2, Type: domain.ActivityListCreated,
+			Payload: json.RawMessage(`{"listId":2}`)},
+		{Id: 2, AggregateType: domain.AggregateItem, AggregateId: 3, Type: domain.ActivityItemCreated,
+			Payload: json.RawMessage(`{"listId":2,"itemId":3}`)},
+		{Id: 3, AggregateType: domain.AggregateActivity, AggregateId: 5, Type: domain.ActivityItemCreated,
+			Payload: json.RawMessage(`{"activityId":5,"listId":2}`)},
+	}}
+	bus := &fakeBus{fail: true}
+	events := &fakeEvents{events: make(chan domain.ActivityEvent, 1)}
+	s := NewOutboxService(repo, bus, events)
+
+	published, err := s.Relay(context.Background(), 10)
+	assert.Error(t, err)
+	assert.Equal(t, 0, published)
+	assert.Len(t, repo.events, 3)
+
+	bus.fail = false
+	published, err = s.Relay(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Empty(t, repo.events)
+	assert.Equal(t, []eventbus.Message{
+		{Id: "1", Type: domain.ActivityListCreated, Key: "list:2", Payload: []byte(`{"listId":2}`)},
+		{Id: "2", Type: domain.ActivityItemCreated, Key: "item:3", Payload: []byte(`{"listId":2,"itemId":3}`)},
+	}, bus.messages)
+
+	// Activity is announced to the event streams instead of the bus.
+	assert.Equal(t, domain.ActivityEvent{ActivityId: 5, ListId: 2}, <-events.events)
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/IvanMeln1k/go-todo-app/internal/domain"
 	"github.com/IvanMeln1k/go-todo-app/internal/repository"
@@ -25,30 +27,158 @@ type Authorization interface {
 
 type TodoList interface {
 	Create(userId int, todoList domain.TodoList) (int, error)
-	GetAll(userId int) ([]domain.TodoList, error)
+	GetAll(userId int, archived bool) ([]domain.TodoList, error)
 	GetById(userId int, todoListId int) (domain.TodoList, error)
-	Delete(userId int, todoListId int) error
-	Update(userId int, todoListId int, updateTodoList domain.UpdateTodoList) (domain.TodoList, error)
+	Delete(userId int, todoListId int, version *int) error
+	Replace(userId int, todoListId int, replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error)
+	Archive(userId int, todoListId int) (domain.TodoList, error)
+	Unarchive(userId int, todoListId int) (domain.TodoList, error)
+	Pin(userId int, todoListId int) (domain.TodoList, error)
+	Unpin(userId int, todoListId int) (domain.TodoList, error)
+	Duplicate(userId int, todoListId int, duplicate domain.DuplicateTodoList) (int, error)
 }
 
 type TodoItem interface {
 	Create(userId int, todoListId int, todoItem domain.TodoItem) (int, error)
 	GetAll(userId int, todoListId int) ([]domain.TodoItem, error)
+	GetAllByLists(userId int, todoListIds []int) (map[int][]domain.TodoItem, error)
 	GetById(userId int, todoItemId int) (domain.TodoItem, error)
-	Delete(userId int, todoItemId int) error
-	Update(userId int, todoItemId int, updateTodoItem domain.UpdateTodoItem) (domain.TodoItem, error)
+	Delete(userId int, todoItemId int, version *int) error
+	Replace(userId int, todoItemId int, replaceTodoItem domain.ReplaceTodoItem) (domain.TodoItem, error)
+	Move(userId int, todoItemId int, todoListId int) (domain.TodoItem, error)
+	Copy(userId int, todoItemId int, todoListId int) (int, error)
+	Bulk(userId int, todoListId int, operations []domain.BulkItemOperation) ([]domain.BulkItemResult, error)
+}
+
+type Trash interface {
+	GetAll(userId int) (domain.Trash, error)
+	RestoreList(userId int, todoListId int) error
+	RestoreItem(userId int, todoItemId int) error
+	DeleteList(userId int, todoListId int) error
+	DeleteItem(userId int, todoItemId int) error
+	Purge(retention time.Duration) (int, error)
+}
+
+type Template interface {
+	Create(userId int, todoListId int, createTemplate domain.CreateListTemplate) (int, error)
+	GetAll(userId int) ([]domain.ListTemplate, error)
+	GetById(userId int, templateId int) (domain.ListTemplate, error)
+	Delete(userId int, templateId int) error
+	Instantiate(userId int, templateId int, instantiate domain.InstantiateListTemplate) (int, error)
+}
+
+type Status interface {
+	GetAll(userId int, todoListId int) ([]domain.Status, error)
+	Create(userId int, todoListId int, status domain.Status) (int, error)
+	Update(userId int, statusId int, updateStatus domain.UpdateStatus) (domain.Status, error)
+	Delete(userId int, statusId int) error
+	SetItemStatus(userId int, todoItemId int, statusId int) (domain.TodoItem, error)
+	GetBoard(userId int, todoListId int) (domain.Board, error)
+}
+
+type Assignee interface {
+	GetAll(userId int, todoItemId int) ([]domain.Assignee, error)
+	GetListMembers(userId int, todoListId int) ([]domain.Assignee, error)
+	GetAllByItems(userId int, todoItemIds []int) (map[int][]domain.Assignee, error)
+	GetListMembersByLists(userId int, todoListIds []int) (map[int][]domain.Assignee, error)
+	Set(userId int, todoItemId int, userIds []int) ([]domain.Assignee, error)
+	GetAssigned(userId int) ([]domain.AssignedTodoItem, error)
+}
+
+type Comment interface {
+	Create(userId int, todoItemId int, input domain.CommentInput) (domain.Comment, error)
+	GetAll(userId int, todoItemId int, limit int, offset int) (domain.CommentsPage, error)
+	Update(userId int, commentId int, input domain.CommentInput) (domain.Comment, error)
+	Delete(userId int, commentId int) error
+}
+
+type Attachment interface {
+	Upload(ctx context.Context, userId int, todoItemId int, name string, r io.Reader) (domain.Attachment, error)
+	GetAll(userId int, todoItemId int) ([]domain.Attachment, error)
+	Open(ctx context.Context, userId int, attachmentId int) (domain.Attachment, io.ReadCloser, error)
+	Delete(userId int, attachmentId int) error
+	CleanupBlobs(ctx context.Context, limit int) (int, error)
+}
+
+type Activity interface {
+	GetByList(userId int, todoListId int, limit int, offset int) (domain.ActivityPage, error)
+	GetByItem(userId int, todoItemId int, limit int, offset int) (domain.ActivityPage, error)
+}
+
+type Events interface {
+	Subscribe(ctx context.Context, userId int, lastEventId int) (<-chan domain.Activity, error)
+}
+
+type Undo interface {
+	UndoLast(userId int) (domain.Activity, error)
+	UndoActivity(userId int, activityId int) (domain.Activity, error)
+}
+
+type Webhook interface {
+	Create(userId int, todoListId int, webhook domain.Webhook) (domain.Webhook, error)
+	GetAll(userId int, todoListId int) ([]domain.Webhook, error)
+	GetById(userId int, webhookId int) (domain.Webhook, error)
+	Update(userId int, webhookId int, input domain.UpdateWebhook) (domain.Webhook, error)
+	Delete(userId int, webhookId int) error
+	GetDeliveries(userId int, webhookId int, limit int, offset int) (domain.WebhookDeliveryPage, error)
+	Redeliver(userId int, deliveryId int) (domain.WebhookDelivery, error)
+	Deliver(ctx context.Context, limit int) (int, error)
+}
+
+type Outbox interface {
+	Relay(ctx context.Context, limit int) (int, error)
+}
+
+type Sync interface {
+	GetChanges(userId int, since string) (domain.SyncChanges, error)
+	Apply(userId int, request domain.SyncRequest) ([]domain.SyncResult, domain.SyncChanges, error)
+}
+
+type Idempotency interface {
+	Begin(ctx context.Context, userId int, key string, fingerprint string) (*domain.IdempotentResponse, error)
+	Complete(ctx context.Context, userId int, key string, response domain.IdempotentResponse) error
+	Abort(ctx context.Context, userId int, key string) error
 }
 
 type Service struct {
 	Authorization
 	TodoList
 	TodoItem
+	Trash
+	Template
+	Status
+	Assignee
+	Comment
+	Attachment
+	Activity
+	Events
+	Undo
+	Webhook
+	Outbox
+	Sync
+	Idempotency
 }
 
 func NewService(repos *repository.Repository) *Service {
+	todoList := NewTodoListService(repos.TodoList)
+	todoItem := NewTodoItemService(repos.TodoItem, repos.TodoList)
+
 	return &Service{
 		Authorization: NewAuthService(repos.Authorization),
-		TodoList:      NewTodoListService(repos.TodoList),
-		TodoItem:      NewTodoItemService(repos.TodoItem, repos.TodoList),
+		TodoList:      todoList,
+		TodoItem:      todoItem,
+		Trash:         NewTrashService(repos.Trash),
+		Template:      NewTemplateService(repos.Template, repos.TodoList),
+		Status:        NewStatusService(repos.Status, repos.TodoList, repos.TodoItem),
+		Assignee:      NewAssigneeService(repos.Assignee, repos.TodoList, repos.TodoItem),
+		Comment:       NewCommentService(repos.Comment, repos.TodoItem),
+		Attachment:    NewAttachmentService(repos.Attachment, repos.TodoList, repos.TodoItem, repos.BlobStore),
+		Activity:      NewActivityService(repos.Activity, repos.TodoList, repos.TodoItem),
+		Events:        NewEventService(repos.Activity, repos.Events),
+		Undo:          NewUndoService(repos.Activity),
+		Webhook:       NewWebhookService(repos.Webhook, repos.TodoList),
+		Outbox:        NewOutboxService(repos.Outbox, repos.EventBus, repos.Events),
+		Sync:          NewSyncService(repos.Sync, todoList, todoItem),
+		Idempotency:   NewIdempotencyService(repos.Idempotency),
 	}
 }
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+type StatusService struct {
+	repo     repository.Status
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+}
+
+func NewStatusService(repo repository.Status, listRepo repository.TodoList, itemRepo repository.TodoItem) *StatusService {
+	return &StatusService{
+		repo:     repo,
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *StatusService) GetAll(userId int, todoListId int) ([]domain.Status, error) {
+	todoList, err := s.listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAll(todoList.Id)
+}
+
+func (s *StatusService) Create(userId int, todoListId int, status domain.Status) (int, error) {
+	todoList, err := getWritableList(s.listRepo, userId, todoListId)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.Create(todoList.Id, status)
+}
+
+func (s *StatusService) getWritableStatus(userId int, statusId int) (domain.Status, error) {
+	status, err := s.repo.GetById(userId, statusId)
+	if err != nil {
+		return status, err
+	}
+	if _, err = getWritableList(s.listRepo, userId, status.ListId); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+func (s *StatusService) Update(userId int, statusId int, updateStatus domain.UpdateStatus) (domain.Status, error) {
+	status, err := s.getWritableStatus(userId, statusId)
+	if err != nil {
+		return status, err
+	}
+
+	return s.repo.Update(status.Id, updateStatus)
+}
+
+func (s *StatusService) Delete(userId int, statusId int) error {
+	status, err := s.getWritableStatus(userId, statusId)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(status.Id)
+}
+
+func (s *StatusService) SetItemStatus(userId int, todoItemId int, statusId int) (domain.TodoItem, error) {
+	if err := checkItemWritable(s.listRepo, userId, todoItemId); err != nil {
+		return domain.TodoItem{}, err
+	}
+
+	return s.repo.SetItemStatus(userId, todoItemId, statusId)
+}
+
+func (s *StatusService) GetBoard(userId int, todoListId int) (domain.Board, error) {
+	var board domain.Board
+
+	todoList, err := s.listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return board, err
+	}
+	board.TodoList = todoList
+
+	statuses, err := s.repo.GetAll(todoList.Id)
+	if err != nil {
+		return board, err
+	}
+
+	todoItems, err := s.itemRepo.GetAll(todoList.Id)
+	if err != nil {
+		return board, err
+	}
+
+	columns := make(map[int]int, len(statuses))
+	board.Columns = make([]domain.BoardColumn, 0, len(statuses))
+	for i, status := range statuses {
+		columns[status.Id] = i
+		board.Columns = append(board.Columns, domain.BoardColumn{
+			Status: status,
+			Items:  make([]domain.TodoItem, 0),
+		})
+	}
+
+	for _, todoItem := range todoItems {
+		if todoItem.StatusId != nil {
+			if i, ok := columns[*todoItem.StatusId]; ok {
+				board.Columns[i].Items = append(board.Columns[i].Items, todoItem)
+				continue
+			}
+		}
+		board.Unassigned = append(board.Unassigned, todoItem)
+	}
+
+	return board, nil
+}
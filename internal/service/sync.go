@@ -0,0 +1,306 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+// syncTokenPrefix marks the format of sync tokens, which clients must not
+// rely on.
+const syncTokenPrefix = "sync:"
+
+// syncPageSize is the number of records returned by a sync at most, the
+// rest is read with the token it returns.
+const syncPageSize = 500
+
+// snapshotPattern matches the text of a pg_snapshot, xmin:xmax:xip_list.
+var snapshotPattern = regexp.MustCompile(`^[0-9]+:[0-9]+:([0-9]+(,[0-9]+)*)?$`)
+
+// SyncService syncs the lists and items of offline clients. Changes from
+// clients go through the list and item services, so they are checked and
+// recorded like any other.
+type SyncService struct {
+	repo  repository.Sync
+	lists TodoList
+	items TodoItem
+}
+
+func NewSyncService(repo repository.Sync, lists TodoList, items TodoItem) *SyncService {
+	return &SyncService{
+		repo:  repo,
+		lists: lists,
+		items: items,
+	}
+}
+
+func encodeSyncToken(cursor domain.SyncCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append([]byte(syncTokenPrefix), data...)), nil
+}
+
+// decodeSyncToken returns the cursor of a token, an empty one for an empty
+// token.
+func decodeSyncToken(token string) (domain.SyncCursor, error) {
+	var cursor domain.SyncCursor
+	if token == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(data), syncTokenPrefix) {
+		return cursor, domain.ErrInvalidSyncToken
+	}
+	decoder := json.NewDecoder(strings.NewReader(strings.TrimPrefix(string(data), syncTokenPrefix)))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&cursor); err != nil || !validSyncCursor(cursor) {
+		return domain.SyncCursor{}, domain.ErrInvalidSyncToken
+	}
+	return cursor, nil
+}
+
+func validSyncCursor(cursor domain.SyncCursor) bool {
+	if cursor.Since != "" && !snapshotPattern.MatchString(cursor.Since) {
+		return false
+	}
+	if cursor.Until == "" {
+		return cursor.Records == "" && cursor.RecordType == "" && cursor.RecordId == 0
+	}
+	if !snapshotPattern.MatchString(cursor.Until) || cursor.RecordId < 0 {
+		return false
+	}
+	switch cursor.Records {
+	case domain.SyncList, domain.SyncItem:
+		return cursor.RecordType == ""
+	case domain.SyncDeleted:
+		return cursor.RecordType == "" || cursor.RecordType == domain.SyncList || cursor.RecordType == domain.SyncItem
+	}
+	return false
+}
+
+// GetChanges returns a page of the changes since the token, everything the
+// user has for an empty one.
+func (s *SyncService) GetChanges(userId int, since string) (domain.SyncChanges, error) {
+	cursor, err := decodeSyncToken(since)
+	if err != nil {
+		return domain.SyncChanges{}, err
+	}
+
+	changes, next, err := s.repo.GetChanges(userId, cursor, syncPageSize)
+	if err != nil {
+		return changes, err
+	}
+	changes.Token, err = encodeSyncToken(next)
+	if err != nil {
+		return changes, err
+	}
+	return changes, nil
+}
+
+// Apply applies the changes of a client in order and returns their results
+// with the first page of the changes since request.Since, the applied ones
+// included. The server wins conflicts: a change made on an outdated version
+// of a record, or on a record deleted meanwhile, is not applied. A change
+// the user is not allowed to make is rejected, the following ones are still
+// applied.
+func (s *SyncService) Apply(userId int, request domain.SyncRequest) ([]domain.SyncResult, domain.SyncChanges, error) {
+	if _, err := decodeSyncToken(request.Since); err != nil {
+		return nil, domain.SyncChanges{}, err
+	}
+
+	results := make([]domain.SyncResult, 0, len(request.Changes))
+	for _, change := range request.Changes {
+		var result domain.SyncResult
+		var err error
+		if change.Type == domain.SyncList {
+			result, err = s.applyList(userId, change)
+		} else {
+			result, err = s.applyItem(userId, change)
+		}
+
+		var domainErr *domain.Error
+		if errors.As(err, &domainErr) {
+			result = domain.SyncResult{Status: domain.SyncRejected, Code: domainErr.Code}
+		} else if err != nil {
+			return nil, domain.SyncChanges{}, err
+		}
+
+		result.Type, result.ClientId = change.Type, change.ClientId
+		if result.Id == 0 && change.Id != nil {
+			result.Id = *change.Id
+		}
+		results = append(results, result)
+	}
+
+	changes, err := s.GetChanges(userId, request.Since)
+	if err != nil {
+		return nil, changes, err
+	}
+	return results, changes, nil
+}
+
+// outdated reports whether a change was made on another version of the
+// record, a change without a base version overwrites any.
+func outdated(change domain.SyncChange, version int) bool {
+	return change.BaseVersion != nil && *change.BaseVersion != version
+}
+
+func (s *SyncService) listResult(userId int, status string, todoListId int) (domain.SyncResult, error) {
+	todoList, err := s.repo.FindList(userId, &todoListId, nil)
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+	return domain.SyncResult{Id: todoList.Id, Status: status, TodoList: &todoList}, nil
+}
+
+func (s *SyncService) applyList(userId int, change domain.SyncChange) (domain.SyncResult, error) {
+	todoList, err := s.repo.FindList(userId, change.Id, change.ClientId)
+	if errors.Is(err, domain.ErrListNotFound) {
+		if change.Op == domain.SyncDelete {
+			return domain.SyncResult{Status: domain.SyncApplied}, nil
+		}
+		if change.Id != nil {
+			return domain.SyncResult{}, err
+		}
+
+		todoListId, err := s.lists.Create(userId, domain.TodoList{Title: *change.Title,
+			Description: change.Description, ClientId: change.ClientId})
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+		return s.listResult(userId, domain.SyncApplied, todoListId)
+	}
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	if todoList.DeletedAt != nil {
+		if change.Op == domain.SyncDelete {
+			return domain.SyncResult{Id: todoList.Id, Status: domain.SyncApplied, TodoList: &todoList}, nil
+		}
+		return domain.SyncResult{Id: todoList.Id, Status: domain.SyncConflict, TodoList: &todoList}, nil
+	}
+	if outdated(change, todoList.Version) {
+		return domain.SyncResult{Id: todoList.Id, Status: domain.SyncConflict, TodoList: &todoList}, nil
+	}
+
+	if change.Op == domain.SyncDelete {
+		err = s.lists.Delete(userId, todoList.Id, change.BaseVersion)
+	} else if todoList.Title != *change.Title || stringValue(todoList.Description) != stringValue(change.Description) {
+		_, err = s.lists.Replace(userId, todoList.Id, domain.ReplaceTodoList{Title: *change.Title,
+			Description: change.Description, Version: change.BaseVersion})
+	}
+	if errors.Is(err, ErrVersionMismatch) {
+		return s.listResult(userId, domain.SyncConflict, todoList.Id)
+	}
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+	return s.listResult(userId, domain.SyncApplied, todoList.Id)
+}
+
+func (s *SyncService) itemResult(userId int, status string, todoItemId int) (domain.SyncResult, error) {
+	todoItem, err := s.repo.FindItem(userId, &todoItemId, nil)
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+	return domain.SyncResult{Id: todoItem.Id, Status: status, TodoItem: &todoItem}, nil
+}
+
+// itemListId returns the list an item change puts the item in, 0 if it
+// leaves the item where it is.
+func (s *SyncService) itemListId(userId int, change domain.SyncChange) (int, error) {
+	if change.ListId == nil && change.ListClientId == nil {
+		return 0, nil
+	}
+	todoList, err := s.repo.FindList(userId, change.ListId, change.ListClientId)
+	return todoList.Id, err
+}
+
+func (s *SyncService) applyItem(userId int, change domain.SyncChange) (domain.SyncResult, error) {
+	todoItem, err := s.repo.FindItem(userId, change.Id, change.ClientId)
+	if errors.Is(err, domain.ErrItemNotFound) {
+		if change.Op == domain.SyncDelete {
+			return domain.SyncResult{Status: domain.SyncApplied}, nil
+		}
+		if change.Id != nil {
+			return domain.SyncResult{}, err
+		}
+
+		todoListId, err := s.itemListId(userId, change)
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+		todoItemId, err := s.items.Create(userId, todoListId, domain.TodoItem{Title: *change.Title,
+			Description: change.Description, Done: change.Done, ClientId: change.ClientId})
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+		return s.itemResult(userId, domain.SyncApplied, todoItemId)
+	}
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	if todoItem.DeletedAt != nil {
+		if change.Op == domain.SyncDelete {
+			return domain.SyncResult{Id: todoItem.Id, Status: domain.SyncApplied, TodoItem: &todoItem}, nil
+		}
+		return domain.SyncResult{Id: todoItem.Id, Status: domain.SyncConflict, TodoItem: &todoItem}, nil
+	}
+	if outdated(change, todoItem.Version) {
+		return domain.SyncResult{Id: todoItem.Id, Status: domain.SyncConflict, TodoItem: &todoItem}, nil
+	}
+
+	if change.Op == domain.SyncDelete {
+		err = s.items.Delete(userId, todoItem.Id, change.BaseVersion)
+		if errors.Is(err, ErrVersionMismatch) {
+			return s.itemResult(userId, domain.SyncConflict, todoItem.Id)
+		}
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+		return s.itemResult(userId, domain.SyncApplied, todoItem.Id)
+	}
+
+	todoListId, err := s.itemListId(userId, change)
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	if todoItem.Title != *change.Title || stringValue(todoItem.Description) != stringValue(change.Description) ||
+		todoItem.Done != change.Done {
+		_, err = s.items.Replace(userId, todoItem.Id, domain.ReplaceTodoItem{Title: *change.Title,
+			Description: change.Description, Done: change.Done, Version: change.BaseVersion})
+		if errors.Is(err, ErrVersionMismatch) {
+			return s.itemResult(userId, domain.SyncConflict, todoItem.Id)
+		}
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+	}
+	// The item is moved once its fields are changed, the move does not
+	// depend on its version.
+	if todoListId != 0 && todoListId != todoItem.ListId {
+		if _, err = s.items.Move(userId, todoItem.Id, todoListId); err != nil {
+			return domain.SyncResult{}, err
+		}
+	}
+	return s.itemResult(userId, domain.SyncApplied, todoItem.Id)
+}
+
+// stringValue turns an optional string into a value comparable with ==.
+func stringValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSyncRepo struct {
+	repository.Sync
+	lists map[int]domain.TodoList
+	since domain.SyncCursor
+}
+
+func (r *fakeSyncRepo) GetChanges(userId int, cursor domain.SyncCursor,
+	limit int) (domain.SyncChanges, domain.SyncCursor, error) {
+	r.since = cursor
+	return domain.SyncChanges{}, domain.SyncCursor{Since: "120:120:"}, nil
+}
+
+func (r *fakeSyncRepo) FindList(userId int, todoListId *int, clientId *string) (domain.TodoList, error) {
+	for _, todoList := range r.lists {
+		if (todoListId == nil || todoList.Id == *todoListId) &&
+			(clientId == nil || todoList.ClientId != nil && *todoList.ClientId == *clientId) {
+			return todoList, nil
+		}
+	}
+	return domain.TodoList{}, domain.ErrListNotFound
+}
+
+// fakeListService changes the lists of a fakeSyncRepo, archived lists cannot
+// be changed.
+type fakeListService struct {
+	TodoList
+	repo *fakeSyncRepo
+}
+
+func (s *fakeListService) Create(userId int, todoList domain.TodoList) (int, error) {
+	todoList.Id = len(s.repo.lists) + 1
+	todoList.Version = 1
+	s.repo.lists[todoList.Id] = todoList
+	return todoList.Id, nil
+}
+
+func (s *fakeListService) Replace(userId int, todoListId int,
+	replaceTodoList domain.ReplaceTodoList) (domain.TodoList, error) {
+	todoList := s.repo.lists[todoListId]
+	if todoList.Archived {
+		return todoList, domain.ErrListArchived
+	}
+	todoList.Title, todoList.Description = replaceTodoList.Title, replaceTodoList.Description
+	todoList.Version++
+	s.repo.lists[todoListId] = todoList
+	return todoList, nil
+}
+
+func TestSyncService_Apply(t *testing.T) {
+	clientId := "4f1c2a8e-6a3b-4f7d-9c41-2b7e0d5a9f10"
+	title, newTitle := "list", "new"
+	deletedAt := time.Now()
+	intPtr := func(n int) *int { return &n }
+
+	repo := &fakeSyncRepo{lists: map[int]domain.TodoList{
+		1: {Id: 1, Title: "list", Version: 3},
+		2: {Id: 2, Title: "list", Version: 1, DeletedAt: &deletedAt},
+		3: {Id: 3, Title: "list", Version: 1, Archived: true},
+	}}
+	s := NewSyncService(repo, &fakeListService{repo: repo}, nil)
+
+	since, err := encodeSyncToken(domain.SyncCursor{Since: "100:104:101"})
+	assert.NoError(t, err)
+	results, changes, err := s.Apply(1, domain.SyncRequest{Since: since, Changes: []domain.SyncChange{
+		{Type: domain.SyncList, Op: domain.SyncUpsert, ClientId: &clientId, Title: &title},
+		{Type: domain.SyncList, Op: domain.SyncUpsert, ClientId: &clientId, Title: &title},
+		{Type: domain.SyncList, Op: domain.SyncUpsert, Id: intPtr(1), BaseVersion: intPtr(2), Title: &newTitle},
+		{Type: domain.SyncList, Op: domain.SyncUpsert, Id: intPtr(1), BaseVersion: intPtr(3), Title: &newTitle},
+		{Type: domain.SyncList, Op: domain.SyncUpsert, Id: intPtr(2), Title: &newTitle},
+		{Type: domain.SyncList, Op: domain.SyncUpsert, Id: intPtr(3), Title: &newTitle},
+		{Type: domain.SyncList, Op: domain.SyncDelete, Id: intPtr(9)},
+	}})
+	assert.NoError(t, err)
+
+	var statuses, codes []string
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+		codes = append(codes, result.Code)
+	}
+	assert.Equal(t, []string{domain.SyncApplied, domain.SyncApplied, domain.SyncConflict, domain.SyncApplied,
+		domain.SyncConflict, domain.SyncRejected, domain.SyncApplied}, statuses)
+	assert.Equal(t, []string{"", "", "", "", "", "list_archived", ""}, codes)
+
+	// The retried creation is matched by its client id.
+	assert.Equal(t, 4, results[0].Id)
+	assert.Equal(t, 4, results[1].Id)
+	assert.Equal(t, 1, results[1].TodoList.Version)
+	assert.Len(t, repo.lists, 4)
+
+	// The server version of a conflicting record is returned.
+	assert.Equal(t, "list", results[2].TodoList.Title)
+	assert.Equal(t, 4, results[3].TodoList.Version)
+	assert.NotNil(t, results[4].TodoList.DeletedAt)
+	assert.Equal(t, 9, results[6].Id)
+
+	assert.Equal(t, domain.SyncCursor{Since: "100:104:101"}, repo.since)
+	token, err := encodeSyncToken(domain.SyncCursor{Since: "120:120:"})
+	assert.NoError(t, err)
+	assert.Equal(t, token, changes.Token)
+}
+
+func TestDecodeSyncToken(t *testing.T) {
+	cursor := domain.SyncCursor{Since: "100:104:101,102", Until: "103:110:",
+		Records: domain.SyncDeleted, RecordType: domain.SyncItem, RecordId: 7}
+	token, err := encodeSyncToken(cursor)
+	assert.NoError(t, err)
+	decoded, err := decodeSyncToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	decoded, err = decodeSyncToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SyncCursor{}, decoded)
+
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+	for _, token := range []string{
+		"745",
+		"!!",
+		encode("xid:745"),
+		encode(`sync:{"since":"1; DROP"}`),
+		encode(`sync:{"since":"100:104:","records":"list"}`),
+		encode(`sync:{"until":"100:104:","records":"tasks"}`),
+		encode(`sync:{"until":"100:104:","records":"list","recordType":"item"}`),
+		encode(`sync:{"until":"100:104:","records":"item","recordId":-1}`),
+		encode(`sync:{"since":"100:104:","cursor":1}`),
+	} {
+		_, err = decodeSyncToken(token)
+		assert.ErrorIs(t, err, domain.ErrInvalidSyncToken, token)
+	}
+}
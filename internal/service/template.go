@@ -0,0 +1,102 @@
+package service
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+type TemplateService struct {
+	repo     repository.Template
+	listRepo repository.TodoList
+}
+
+func NewTemplateService(repo repository.Template, listRepo repository.TodoList) *TemplateService {
+	return &TemplateService{
+		repo:     repo,
+		listRepo: listRepo,
+	}
+}
+
+func (s *TemplateService) Create(userId int, todoListId int, createTemplate domain.CreateListTemplate) (int, error) {
+	return s.repo.CreateFromList(userId, todoListId, createTemplate.Title)
+}
+
+func (s *TemplateService) GetAll(userId int) ([]domain.ListTemplate, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *TemplateService) GetById(userId int, templateId int) (domain.ListTemplate, error) {
+	return s.repo.GetById(userId, templateId)
+}
+
+func (s *TemplateService) Delete(userId int, templateId int) error {
+	return s.repo.Delete(userId, templateId)
+}
+
+func (s *TemplateService) Instantiate(userId int, templateId int, instantiate domain.InstantiateListTemplate) (int, error) {
+	template, err := s.repo.GetById(userId, templateId)
+	if err != nil {
+		return 0, err
+	}
+
+	values := placeholderValues(time.Now(), instantiate.Variables)
+
+	title := template.Title
+	if instantiate.Title != nil {
+		title = *instantiate.Title
+	}
+	todoList := domain.TodoList{
+		Title:       fillPlaceholders(title, values),
+		Description: fillNullablePlaceholders(template.Description, values),
+	}
+
+	todoItems := make([]domain.TodoItem, 0, len(template.Items))
+	for _, item := range template.Items {
+		todoItems = append(todoItems, domain.TodoItem{
+			Title:       fillPlaceholders(item.Title, values),
+			Description: fillNullablePlaceholders(item.Description, values),
+		})
+	}
+
+	return s.listRepo.CreateWithItems(userId, todoList, todoItems)
+}
+
+// placeholderValues returns the built-in placeholders for now, overridden by
+// the variables passed by the client.
+func placeholderValues(now time.Time, variables map[string]string) map[string]string {
+	values := map[string]string{
+		"date":     now.Format("2006-01-02"),
+		"time":     now.Format("15:04"),
+		"datetime": now.Format("2006-01-02 15:04"),
+		"weekday":  now.Weekday().String(),
+	}
+	for name, value := range variables {
+		values[name] = value
+	}
+	return values
+}
+
+// fillPlaceholders replaces {{name}} with its value. Unknown placeholders are
+// kept as is.
+func fillPlaceholders(text string, values map[string]string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+func fillNullablePlaceholders(text *string, values map[string]string) *string {
+	if text == nil {
+		return nil
+	}
+	filled := fillPlaceholders(*text, values)
+	return &filled
+}
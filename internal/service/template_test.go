@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillPlaceholders(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name      string
+		text      string
+		variables map[string]string
+		expected  string
+	}{
+		{
+			name:     "date",
+			text:     "Release {{date}}",
+			expected: "Release 2024-03-01",
+		},
+		{
+			name:     "spaces inside braces",
+			text:     "{{ weekday }} standup at {{time}}",
+			expected: "Friday standup at 09:30",
+		},
+		{
+			name:      "client variable",
+			text:      "Onboarding for {{name}}",
+			variables: map[string]string{"name": "Ivan"},
+			expected:  "Onboarding for Ivan",
+		},
+		{
+			name:      "client variable overrides built-in",
+			text:      "{{date}}",
+			variables: map[string]string{"date": "tomorrow"},
+			expected:  "tomorrow",
+		},
+		{
+			name:     "unknown placeholder",
+			text:     "{{unknown}} stays",
+			expected: "{{unknown}} stays",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			values := placeholderValues(now, testCase.variables)
+			assert.Equal(t, testCase.expected, fillPlaceholders(testCase.text, values))
+		})
+	}
+}
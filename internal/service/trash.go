@@ -0,0 +1,42 @@
+package service
+
+import (
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+type TrashService struct {
+	repo repository.Trash
+}
+
+func NewTrashService(repo repository.Trash) *TrashService {
+	return &TrashService{
+		repo: repo,
+	}
+}
+
+func (s *TrashService) GetAll(userId int) (domain.Trash, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *TrashService) RestoreList(userId int, todoListId int) error {
+	return s.repo.RestoreList(userId, todoListId)
+}
+
+func (s *TrashService) RestoreItem(userId int, todoItemId int) error {
+	return s.repo.RestoreItem(userId, todoItemId)
+}
+
+func (s *TrashService) DeleteList(userId int, todoListId int) error {
+	return s.repo.DeleteList(userId, todoListId)
+}
+
+func (s *TrashService) DeleteItem(userId int, todoItemId int) error {
+	return s.repo.DeleteItem(userId, todoItemId)
+}
+
+func (s *TrashService) Purge(retention time.Duration) (int, error) {
+	return s.repo.Purge(retention)
+}
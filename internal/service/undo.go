@@ -0,0 +1,91 @@
+package service
+
+import (
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+// UndoWindow is how long after an operation it can still be undone.
+const UndoWindow = 15 * time.Minute
+
+var (
+	ErrNothingToUndo     = domain.NewNotFoundError("nothing_to_undo", "Nothing to undo")
+	ErrUndoWindowExpired = domain.NewConflictError("undo_window_expired", "Undo window has expired")
+	ErrNotActivityAuthor = domain.NewForbiddenError("not_activity_author", "Only the author can undo the operation")
+)
+
+var undoableActions = []string{
+	domain.ActivityListUpdated,
+	domain.ActivityListDeleted,
+	domain.ActivityItemUpdated,
+	domain.ActivityItemCompleted,
+	domain.ActivityItemReopened,
+	domain.ActivityItemDeleted,
+	domain.ActivityItemMoved,
+}
+
+type UndoService struct {
+	activityRepo repository.Activity
+}
+
+func NewUndoService(activityRepo repository.Activity) *UndoService {
+	return &UndoService{
+		activityRepo: activityRepo,
+	}
+}
+
+// UndoLast undoes the latest operation of the user that is not undone yet.
+func (s *UndoService) UndoLast(userId int) (domain.Activity, error) {
+	activity, err := s.activityRepo.GetLastUndoable(userId, undoableActions, time.Now().Add(-UndoWindow))
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return activity, ErrNothingToUndo
+		}
+		return activity, err
+	}
+
+	return s.undo(userId, activity)
+}
+
+func (s *UndoService) UndoActivity(userId int, activityId int) (domain.Activity, error) {
+	activity, err := s.activityRepo.GetById(userId, activityId)
+	if err != nil {
+		return activity, err
+	}
+
+	if activity.Actor.Id != userId {
+		return activity, ErrNotActivityAuthor
+	}
+	if activity.UndoOf != nil || !isUndoable(activity.Action) {
+		return activity, domain.ErrNotUndoable
+	}
+	if activity.Undone {
+		return activity, domain.ErrAlreadyUndone
+	}
+	if activity.CreatedAt.Before(time.Now().Add(-UndoWindow)) {
+		return activity, ErrUndoWindowExpired
+	}
+
+	return s.undo(userId, activity)
+}
+
+func isUndoable(action string) bool {
+	for _, undoable := range undoableActions {
+		if action == undoable {
+			return true
+		}
+	}
+	return false
+}
+
+// undo reverts the operation and returns the activity recorded for the undo.
+func (s *UndoService) undo(userId int, activity domain.Activity) (domain.Activity, error) {
+	activityId, err := s.activityRepo.Undo(userId, activity)
+	if err != nil {
+		return domain.Activity{}, err
+	}
+
+	return s.activityRepo.GetById(userId, activityId)
+}
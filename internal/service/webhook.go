@@ -0,0 +1,327 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+)
+
+const (
+	// maxDeliveryAttempts is the number of times a delivery is sent before it
+	// is given up.
+	maxDeliveryAttempts = 8
+	// deliveryBackoff is the delay before the first retry, each next one
+	// waits twice as long.
+	deliveryBackoff = 30 * time.Second
+	// maxWebhookFailures is the number of failed attempts in a row after
+	// which a webhook is disabled.
+	maxWebhookFailures = 10
+	// deliveryTimeout bounds a single attempt, deliveryLease must cover a
+	// whole batch of them.
+	deliveryTimeout = 10 * time.Second
+	deliveryLease   = 5 * time.Minute
+)
+
+var ErrWebhookInactive = domain.NewConflictError("webhook_inactive", "Webhook is inactive")
+
+type WebhookService struct {
+	repo     repository.Webhook
+	listRepo repository.TodoList
+	client   *http.Client
+}
+
+func NewWebhookService(repo repository.Webhook, listRepo repository.TodoList) *WebhookService {
+	return &WebhookService{
+		repo:     repo,
+		listRepo: listRepo,
+		client:   newWebhookClient(),
+	}
+}
+
+// Create registers a webhook on the list. The returned webhook holds the
+// secret its deliveries are signed with, it cannot be read later.
+func (s *WebhookService) Create(userId int, todoListId int, webhook domain.Webhook) (domain.Webhook, error) {
+	if _, err := getWritableList(s.listRepo, userId, todoListId); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.ListId = todoListId
+	webhook.Secret = secret
+
+	webhookId, err := s.repo.Create(userId, webhook)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	created, err := s.repo.GetById(userId, webhookId)
+	if err != nil {
+		return created, err
+	}
+	created.Secret = secret
+	return created, nil
+}
+
+func (s *WebhookService) GetAll(userId int, todoListId int) ([]domain.Webhook, error) {
+	todoList, err := s.listRepo.GetById(userId, todoListId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAll(todoList.Id)
+}
+
+func (s *WebhookService) GetById(userId int, webhookId int) (domain.Webhook, error) {
+	return s.repo.GetById(userId, webhookId)
+}
+
+func (s *WebhookService) Update(userId int, webhookId int, input domain.UpdateWebhook) (domain.Webhook, error) {
+	webhook, err := s.getWritable(userId, webhookId)
+	if err != nil {
+		return webhook, err
+	}
+
+	return s.repo.Update(webhook.Id, input)
+}
+
+func (s *WebhookService) Delete(userId int, webhookId int) error {
+	webhook, err := s.getWritable(userId, webhookId)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Delete(webhook.Id)
+}
+
+func (s *WebhookService) GetDeliveries(userId int, webhookId int, limit int,
+	offset int) (domain.WebhookDeliveryPage, error) {
+	webhook, err := s.repo.GetById(userId, webhookId)
+	if err != nil {
+		return domain.WebhookDeliveryPage{}, err
+	}
+
+	deliveries, total, err := s.repo.GetDeliveries(webhook.Id, limit, offset)
+	if err != nil {
+		return domain.WebhookDeliveryPage{}, err
+	}
+
+	return domain.WebhookDeliveryPage{
+		Deliveries: deliveries,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+	}, nil
+}
+
+// Redeliver queues the activity of a delivery to be sent again, whatever
+// became of the delivery.
+func (s *WebhookService) Redeliver(userId int, deliveryId int) (domain.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDeliveryById(userId, deliveryId)
+	if err != nil {
+		return delivery, err
+	}
+
+	webhook, err := s.getWritable(userId, delivery.WebhookId)
+	if err != nil {
+		return delivery, err
+	}
+	if !webhook.Active {
+		return delivery, ErrWebhookInactive
+	}
+
+	return s.repo.Redeliver(delivery.Id)
+}
+
+// Deliver sends up to limit deliveries that are due and returns how many were
+// sent, successfully or not. A failed delivery is retried with exponential
+// backoff until it runs out of attempts.
+func (s *WebhookService) Deliver(ctx context.Context, limit int) (int, error) {
+	deliveries, err := s.repo.ClaimDeliveries(limit, deliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		var attempt domain.DeliveryAttempt
+		activity, err := s.repo.GetActivity(delivery.ActivityId)
+		if err != nil {
+			// The payload cannot be built, the delivery is retried like one
+			// the webhook failed and the rest of the batch is still sent.
+			attempt = failedAttempt(delivery, err)
+		} else {
+			attempt = s.send(ctx, delivery, activity)
+			if err = ctx.Err(); err != nil {
+				// An attempt cut short by shutdown is not counted, the
+				// delivery is sent again when its lease ends.
+				return sent, err
+			}
+		}
+		if !attempt.Succeeded && delivery.Attempts+1 < maxDeliveryAttempts {
+			nextAttemptAt := time.Now().Add(retryDelay(delivery.Attempts + 1))
+			attempt.NextAttemptAt = &nextAttemptAt
+		}
+		if err = s.repo.RecordAttempt(attempt, maxWebhookFailures); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// send posts the activity to the webhook. Any 2xx response is a success.
+func (s *WebhookService) send(ctx context.Context, delivery domain.PendingDelivery,
+	activity domain.Activity) domain.DeliveryAttempt {
+	attempt := domain.DeliveryAttempt{
+		DeliveryId: delivery.Id,
+		WebhookId:  delivery.WebhookId,
+	}
+	fail := func(err error) domain.DeliveryAttempt {
+		message := err.Error()
+		attempt.Error = &message
+		return attempt
+	}
+
+	body, err := json.Marshal(domain.WebhookPayload{
+		DeliveryId: delivery.Id,
+		WebhookId:  delivery.WebhookId,
+		Event:      delivery.Event,
+		Activity:   activity,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return fail(err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-todo-app-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(delivery.Id))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signPayload(delivery.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+	// The body is drained, so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.ResponseCode = &resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fail(fmt.Errorf("unexpected response status %d", resp.StatusCode))
+	}
+	attempt.Succeeded = true
+	return attempt
+}
+
+func failedAttempt(delivery domain.PendingDelivery, err error) domain.DeliveryAttempt {
+	message := err.Error()
+	return domain.DeliveryAttempt{
+		DeliveryId: delivery.Id,
+		WebhookId:  delivery.WebhookId,
+		Error:      &message,
+	}
+}
+
+// newWebhookClient returns the client deliveries are sent with. Webhook URLs
+// come from users, so it connects only to public addresses, without a proxy,
+// and does not follow redirects, which could lead to an internal address. A
+// redirect is returned as the response and counts as a failure.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: checkWebhookAddress,
+	}
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: deliveryTimeout,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkWebhookAddress rejects a connection to a loopback, private,
+// link-local, multicast or unspecified address. It runs on the resolved
+// address, so a host name pointing inside the network is rejected too.
+func checkWebhookAddress(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
+
+// signPayload returns the X-Webhook-Signature of a delivery: the hex
+// HMAC-SHA256 of the timestamp and the body joined by a dot, keyed with the
+// webhook's secret. Receivers check it and reject old timestamps to stop
+// replays.
+func signPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the delay before retrying a delivery that failed the
+// given number of attempts.
+func retryDelay(attempts int) time.Duration {
+	return deliveryBackoff << (attempts - 1)
+}
+
+// getWritable returns the webhook if the user can change its list.
+func (s *WebhookService) getWritable(userId int, webhookId int) (domain.Webhook, error) {
+	webhook, err := s.repo.GetById(userId, webhookId)
+	if err != nil {
+		return webhook, err
+	}
+
+	if _, err = getWritableList(s.listRepo, userId, webhook.ListId); err != nil {
+		return webhook, err
+	}
+	return webhook, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
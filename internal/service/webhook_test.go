@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWebhooks hands out its deliveries once and keeps the attempts recorded
+// for them.
+type fakeWebhooks struct {
+	repository.Webhook
+	deliveries []domain.PendingDelivery
+	attempts   []domain.DeliveryAttempt
+}
+
+func (r *fakeWebhooks) ClaimDeliveries(limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	deliveries := r.deliveries
+	r.deliveries = nil
+	return deliveries, nil
+}
+
+// GetActivity fails for the activity with id 4.
+func (r *fakeWebhooks) GetActivity(activityId int) (domain.Activity, error) {
+	if activityId == 4 {
+		return domain.Activity{}, errors.New("connection reset")
+	}
+	return domain.Activity{Id: activityId, ListId: 1, Action: domain.ActivityItemCreated}, nil
+}
+
+func (r *fakeWebhooks) RecordAttempt(attempt domain.DeliveryAttempt, maxFailures int) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func TestWebhookService_Deliver(t *testing.T) {
+	type request struct {
+		header  http.Header
+		payload domain.WebhookPayload
+		body    []byte
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload domain.WebhookPayload
+		json.Unmarshal(body, &payload)
+		requests = append(requests, request{header: r.Header, payload: payload, body: body})
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(500)
+		}
+	}))
+	defer server.Close()
+
+	delivery := func(id int, path string, attempts int) domain.PendingDelivery {
+		return domain.PendingDelivery{
+			WebhookDelivery: domain.WebhookDelivery{Id: id, WebhookId: 2, ActivityId: 3,
+				Event: domain.ActivityItemCreated, Attempts: attempts},
+			URL:    server.URL + path,
+			Secret: "secret",
+		}
+	}
+	repo := &fakeWebhooks{deliveries: []domain.PendingDelivery{
+		delivery(1, "/ok", 0),
+		delivery(2, "/fail", 2),
+		delivery(3, "/fail", maxDeliveryAttempts-1),
+		delivery(4, "/ok", 0),
+		delivery(5, "/ok", 0),
+	}}
+	repo.deliveries[3].ActivityId = 4
+	s := NewWebhookService(repo, nil)
+	// The test server listens on a loopback address.
+	s.client = server.Client()
+
+	start := time.Now()
+	sent, err := s.Deliver(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, sent)
+
+	if assert.Len(t, requests, 4) {
+		header := requests[0].header
+		assert.Equal(t, domain.ActivityItemCreated, header.Get("X-Webhook-Event"))
+		assert.Equal(t, "1", header.Get("X-Webhook-Delivery"))
+		assert.Equal(t, signPayload("secret", header.Get("X-Webhook-Timestamp"), requests[0].body),
+			header.Get("X-Webhook-Signature"))
+		assert.Equal(t, domain.WebhookPayload{DeliveryId: 1, WebhookId: 2, Event: domain.ActivityItemCreated,
+			Activity: domain.Activity{Id: 3, ListId: 1, Action: domain.ActivityItemCreated}}, requests[0].payload)
+	}
+
+	if assert.Len(t, repo.attempts, 5) {
+		ok, retried, failed := repo.attempts[0], repo.attempts[1], repo.attempts[2]
+		unread, next := repo.attempts[3], repo.attempts[4]
+
+		assert.True(t, ok.Succeeded)
+		assert.Equal(t, 200, *ok.ResponseCode)
+		assert.Nil(t, ok.NextAttemptAt)
+
+		assert.False(t, retried.Succeeded)
+		assert.Equal(t, 500, *retried.ResponseCode)
+		assert.Equal(t, "unexpected response status 500", *retried.Error)
+		if assert.NotNil(t, retried.NextAttemptAt) {
+			assert.WithinDuration(t, start.Add(4*deliveryBackoff), *retried.NextAttemptAt, time.Second)
+		}
+
+		assert.False(t, failed.Succeeded)
+		assert.Nil(t, failed.NextAttemptAt)
+
+		// The activity of a delivery could not be read, the delivery is
+		// retried and the next one is still sent.
+		assert.False(t, unread.Succeeded)
+		assert.Nil(t, unread.ResponseCode)
+		assert.Equal(t, "connection reset", *unread.Error)
+		assert.NotNil(t, unread.NextAttemptAt)
+		assert.True(t, next.Succeeded)
+	}
+}
+
+func TestWebhookService_Deliver_privateAddress(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhooks{deliveries: []domain.PendingDelivery{{
+		WebhookDelivery: domain.WebhookDelivery{Id: 1, WebhookId: 2, ActivityId: 3, Event: domain.ActivityItemCreated},
+		URL:             server.URL,
+	}}}
+	s := NewWebhookService(repo, nil)
+
+	_, err := s.Deliver(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Zero(t, requests)
+	if assert.Len(t, repo.attempts, 1) {
+		assert.False(t, repo.attempts[0].Succeeded)
+		assert.Contains(t, *repo.attempts[0].Error, "is not public")
+	}
+}
+
+func TestWebhookService_Deliver_redirect(t *testing.T) {
+	var redirected bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhooks{deliveries: []domain.PendingDelivery{{
+		WebhookDelivery: domain.WebhookDelivery{Id: 1, WebhookId: 2, ActivityId: 3, Event: domain.ActivityItemCreated},
+		URL:             server.URL,
+	}}}
+	s := NewWebhookService(repo, nil)
+	// The test server listens on a loopback address, only the redirect
+	// policy of the client is kept.
+	client := server.Client()
+	client.CheckRedirect = newWebhookClient().CheckRedirect
+	s.client = client
+
+	_, err := s.Deliver(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.False(t, redirected)
+	if assert.Len(t, repo.attempts, 1) {
+		assert.False(t, repo.attempts[0].Succeeded)
+		assert.Equal(t, http.StatusFound, *repo.attempts[0].ResponseCode)
+	}
+}
+
+func TestCheckWebhookAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{address: "93.184.216.34:443", public: true},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443", public: true},
+		{address: "127.0.0.1:80", public: false},
+		{address: "[::1]:80", public: false},
+		{address: "10.0.0.1:80", public: false},
+		{address: "172.16.0.1:80", public: false},
+		{address: "192.168.1.1:80", public: false},
+		{address: "[fd00::1]:80", public: false},
+		{address: "169.254.169.254:80", public: false},
+		{address: "[fe80::1]:80", public: false},
+		{address: "0.0.0.0:80", public: false},
+		{address: "[::ffff:127.0.0.1]:80", public: false},
+		{address: "224.0.0.1:80", public: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			err := checkWebhookAddress("tcp", test.address, nil)
+			if test.public {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSignPayload(t *testing.T) {
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		signPayload("secret", "1700000000", []byte(`{}`)))
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	blobCleanupBatch = 100
+	// defaultBlobCleanupInterval is used when the configuration has none.
+	defaultBlobCleanupInterval = 10 * time.Minute
+)
+
+type BlobCleaner struct {
+	attachments service.Attachment
+	interval    time.Duration
+}
+
+func NewBlobCleaner(attachments service.Attachment, interval time.Duration) *BlobCleaner {
+	if interval <= 0 {
+		interval = defaultBlobCleanupInterval
+	}
+	return &BlobCleaner{
+		attachments: attachments,
+		interval:    interval,
+	}
+}
+
+func (w *BlobCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			deleted, err := w.attachments.CleanupBlobs(ctx, blobCleanupBatch)
+			if err != nil {
+				logrus.Errorf("error cleaning up blobs: %s", err.Error())
+				break
+			}
+			if deleted > 0 {
+				logrus.Infof("deleted %d orphaned blobs", deleted)
+			}
+			if deleted < blobCleanupBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
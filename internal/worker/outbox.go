@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	outboxRelayBatch = 100
+	// defaultOutboxRelayInterval is used when the configuration has none.
+	defaultOutboxRelayInterval = time.Second
+)
+
+type OutboxRelay struct {
+	outbox   service.Outbox
+	interval time.Duration
+}
+
+func NewOutboxRelay(outbox service.Outbox, interval time.Duration) *OutboxRelay {
+	if interval <= 0 {
+		interval = defaultOutboxRelayInterval
+	}
+	return &OutboxRelay{
+		outbox:   outbox,
+		interval: interval,
+	}
+}
+
+func (w *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			published, err := w.outbox.Relay(ctx, outboxRelayBatch)
+			if err != nil {
+				logrus.Errorf("error relaying outbox: %s", err.Error())
+				break
+			}
+			if published < outboxRelayBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultTrashRetention and defaultPurgeInterval are used when the
+	// configuration has none, a missing retention must not empty the trash.
+	defaultTrashRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = time.Hour
+)
+
+type TrashPurger struct {
+	trash     service.Trash
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewTrashPurger(trash service.Trash, retention time.Duration, interval time.Duration) *TrashPurger {
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	return &TrashPurger{
+		trash:     trash,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+func (w *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := w.trash.Purge(w.retention)
+		if err != nil {
+			logrus.Errorf("error purging trash: %s", err.Error())
+		} else if purged > 0 {
+			logrus.Infof("purged %d entries from trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	webhookDeliveryBatch = 20
+	// defaultWebhookDispatchInterval is used when the configuration has none.
+	defaultWebhookDispatchInterval = 5 * time.Second
+)
+
+type WebhookDispatcher struct {
+	webhooks service.Webhook
+	interval time.Duration
+}
+
+func NewWebhookDispatcher(webhooks service.Webhook, interval time.Duration) *WebhookDispatcher {
+	if interval <= 0 {
+		interval = defaultWebhookDispatchInterval
+	}
+	return &WebhookDispatcher{
+		webhooks: webhooks,
+		interval: interval,
+	}
+}
+
+func (w *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			sent, err := w.webhooks.Deliver(ctx, webhookDeliveryBatch)
+			if err != nil {
+				logrus.Errorf("error delivering webhooks: %s", err.Error())
+				break
+			}
+			if sent < webhookDeliveryBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE todo_lists DROP COLUMN pinned;
+
+ALTER TABLE todo_lists DROP COLUMN archived;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE todo_lists ADD COLUMN archived bool NOT NULL DEFAULT false;
+
+ALTER TABLE todo_lists ADD COLUMN pinned bool NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE template_items;
+
+DROP TABLE list_templates;
\ No newline at end of file
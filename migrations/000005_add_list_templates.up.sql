@@ -0,0 +1,15 @@
+CREATE TABLE list_templates (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  description VARCHAR(255),
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE template_items (
+  id BIGSERIAL PRIMARY KEY,
+  template_id BIGINT NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  description VARCHAR(255),
+  FOREIGN KEY (template_id) REFERENCES list_templates (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE todo_items DROP COLUMN status_id;
+
+DROP TABLE list_statuses;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE list_statuses (
+  id BIGSERIAL PRIMARY KEY,
+  list_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  position INT NOT NULL,
+  is_done bool NOT NULL DEFAULT false,
+  FOREIGN KEY (list_id) REFERENCES todo_lists (id) ON DELETE CASCADE
+);
+
+ALTER TABLE todo_items ADD COLUMN status_id BIGINT;
+
+ALTER TABLE todo_items ADD FOREIGN KEY (status_id) REFERENCES list_statuses (id) ON DELETE SET NULL;
+
+INSERT INTO list_statuses (list_id, name, position, is_done)
+SELECT tl.id, s.name, s.position, s.is_done FROM todo_lists tl CROSS JOIN (VALUES
+  ('todo', 1, false), ('in progress', 2, false), ('blocked', 3, false), ('review', 4, false), ('done', 5, true)
+) AS s (name, position, is_done);
+
+UPDATE todo_items ti SET status_id = ls.id FROM lists_items li, list_statuses ls
+WHERE li.item_id = ti.id AND ls.list_id = li.list_id AND ls.name = CASE WHEN ti.done THEN 'done' ELSE 'todo' END;
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP TRIGGER lists_items_remove_assignees ON lists_items;
+
+DROP FUNCTION remove_moved_item_assignees;
+
+DROP TRIGGER users_lists_remove_assignees ON users_lists;
+
+DROP FUNCTION remove_revoked_assignees;
+
+DROP TABLE items_assignees;
\ No newline at end of file
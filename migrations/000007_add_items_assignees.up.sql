@@ -0,0 +1,31 @@
+CREATE TABLE items_assignees (
+  id BIGSERIAL PRIMARY KEY,
+  item_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  UNIQUE (item_id, user_id),
+  FOREIGN KEY (item_id) REFERENCES todo_items (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE FUNCTION remove_revoked_assignees() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM items_assignees ia USING lists_items li
+  WHERE li.item_id = ia.item_id AND li.list_id = OLD.list_id AND ia.user_id = OLD.user_id
+  AND NOT EXISTS (SELECT 1 FROM users_lists ul WHERE ul.list_id = OLD.list_id AND ul.user_id = OLD.user_id);
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_lists_remove_assignees AFTER DELETE OR UPDATE ON users_lists
+FOR EACH ROW EXECUTE FUNCTION remove_revoked_assignees();
+
+CREATE FUNCTION remove_moved_item_assignees() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM items_assignees ia WHERE ia.item_id = NEW.item_id
+  AND NOT EXISTS (SELECT 1 FROM users_lists ul WHERE ul.list_id = NEW.list_id AND ul.user_id = ia.user_id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER lists_items_remove_assignees AFTER UPDATE OF list_id ON lists_items
+FOR EACH ROW EXECUTE FUNCTION remove_moved_item_assignees();
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE item_comments;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE item_comments (
+  id BIGSERIAL PRIMARY KEY,
+  item_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  FOREIGN KEY (item_id) REFERENCES todo_items (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX item_comments_item_id_created_at_idx ON item_comments (item_id, created_at DESC);
\ No newline at end of file
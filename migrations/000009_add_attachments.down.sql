@@ -0,0 +1,7 @@
+DROP TRIGGER queue_attachment_blob ON attachments;
+
+DROP FUNCTION queue_attachment_blob;
+
+DROP TABLE orphaned_blobs;
+
+DROP TABLE attachments;
\ No newline at end of file
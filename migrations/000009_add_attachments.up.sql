@@ -0,0 +1,30 @@
+CREATE TABLE attachments (
+  id BIGSERIAL PRIMARY KEY,
+  item_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  size BIGINT NOT NULL,
+  content_type VARCHAR(255) NOT NULL,
+  checksum CHAR(64) NOT NULL,
+  storage_key VARCHAR(255) NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  FOREIGN KEY (item_id) REFERENCES todo_items (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX attachments_item_id_idx ON attachments (item_id);
+
+CREATE TABLE orphaned_blobs (
+  storage_key VARCHAR(255) PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE FUNCTION queue_attachment_blob() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO orphaned_blobs (storage_key) VALUES (OLD.storage_key) ON CONFLICT DO NOTHING;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER queue_attachment_blob AFTER DELETE ON attachments
+FOR EACH ROW EXECUTE FUNCTION queue_attachment_blob();
\ No newline at end of file
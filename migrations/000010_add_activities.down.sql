@@ -0,0 +1,5 @@
+DROP TRIGGER activities_append_only ON activities;
+
+DROP FUNCTION forbid_activity_update;
+
+DROP TABLE activities;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE activities (
+  id BIGSERIAL PRIMARY KEY,
+  list_id BIGINT NOT NULL,
+  item_id BIGINT,
+  user_id BIGINT NOT NULL,
+  action VARCHAR(50) NOT NULL,
+  changes JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  FOREIGN KEY (list_id) REFERENCES todo_lists (id) ON DELETE CASCADE,
+  FOREIGN KEY (item_id) REFERENCES todo_items (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX activities_list_id_created_at_idx ON activities (list_id, created_at DESC);
+
+CREATE INDEX activities_item_id_created_at_idx ON activities (item_id, created_at DESC);
+
+CREATE FUNCTION forbid_activity_update() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'activities are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activities_append_only BEFORE UPDATE ON activities
+FOR EACH ROW EXECUTE FUNCTION forbid_activity_update();
\ No newline at end of file
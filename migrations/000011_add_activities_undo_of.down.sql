@@ -0,0 +1 @@
+ALTER TABLE activities DROP COLUMN undo_of;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE activities ADD COLUMN undo_of BIGINT REFERENCES activities (id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX activities_undo_of_idx ON activities (undo_of);
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP TRIGGER todo_items_bump_version ON todo_items;
+
+DROP TRIGGER todo_lists_bump_version ON todo_lists;
+
+DROP FUNCTION bump_version;
+
+ALTER TABLE todo_items DROP COLUMN version;
+
+ALTER TABLE todo_lists DROP COLUMN version;
\ No newline at end of file
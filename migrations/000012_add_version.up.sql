@@ -0,0 +1,18 @@
+ALTER TABLE todo_lists ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE todo_items ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+  IF NEW IS DISTINCT FROM OLD THEN
+    NEW.version := OLD.version + 1;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_lists_bump_version BEFORE UPDATE ON todo_lists
+FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+CREATE TRIGGER todo_items_bump_version BEFORE UPDATE ON todo_items
+FOR EACH ROW EXECUTE FUNCTION bump_version();
\ No newline at end of file
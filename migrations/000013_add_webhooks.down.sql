@@ -0,0 +1,3 @@
+DROP TABLE webhook_deliveries;
+
+DROP TABLE webhooks;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE webhooks (
+  id BIGSERIAL PRIMARY KEY,
+  list_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  url VARCHAR(255) NOT NULL,
+  secret CHAR(64) NOT NULL,
+  events VARCHAR(50)[] NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT true,
+  failures INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  FOREIGN KEY (list_id) REFERENCES todo_lists (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhooks_list_id_idx ON webhooks (list_id);
+
+CREATE TABLE webhook_deliveries (
+  id BIGSERIAL PRIMARY KEY,
+  webhook_id BIGINT NOT NULL,
+  activity_id BIGINT NOT NULL,
+  event VARCHAR(50) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+  response_code INTEGER,
+  error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMP,
+  FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE,
+  FOREIGN KEY (activity_id) REFERENCES activities (id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at DESC);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
\ No newline at end of file
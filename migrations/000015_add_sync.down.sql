@@ -0,0 +1,29 @@
+DROP TRIGGER users_lists_track_access ON users_lists;
+
+DROP FUNCTION track_list_access;
+
+DROP TRIGGER todo_lists_track_delete ON todo_lists;
+
+DROP FUNCTION track_list_delete;
+
+DROP TRIGGER todo_items_track_delete ON todo_items;
+
+DROP FUNCTION track_item_delete;
+
+DROP TRIGGER lists_items_track_move ON lists_items;
+
+DROP FUNCTION track_item_move;
+
+DROP TRIGGER todo_items_track_change ON todo_items;
+
+DROP TRIGGER todo_lists_track_change ON todo_lists;
+
+DROP FUNCTION track_change;
+
+DROP TABLE sync_tombstones;
+
+DROP TABLE sync_changes;
+
+ALTER TABLE todo_items DROP COLUMN client_id;
+
+ALTER TABLE todo_lists DROP COLUMN client_id;
\ No newline at end of file
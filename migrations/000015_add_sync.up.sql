@@ -0,0 +1,92 @@
+ALTER TABLE todo_lists ADD COLUMN client_id UUID UNIQUE;
+
+ALTER TABLE todo_items ADD COLUMN client_id UUID UNIQUE;
+
+CREATE TABLE sync_changes (
+  record_type VARCHAR(10) NOT NULL,
+  record_id BIGINT NOT NULL,
+  change_xid xid8 NOT NULL,
+  PRIMARY KEY (record_type, record_id)
+);
+
+CREATE INDEX sync_changes_change_xid_idx ON sync_changes (change_xid);
+
+CREATE TABLE sync_tombstones (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  record_type VARCHAR(10) NOT NULL,
+  record_id BIGINT NOT NULL,
+  change_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX sync_tombstones_user_id_change_xid_idx ON sync_tombstones (user_id, change_xid);
+
+CREATE FUNCTION track_change() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO sync_changes (record_type, record_id, change_xid)
+  VALUES (TG_ARGV[0], NEW.id, pg_current_xact_id())
+  ON CONFLICT (record_type, record_id) DO UPDATE SET change_xid = EXCLUDED.change_xid;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_lists_track_change AFTER INSERT OR UPDATE ON todo_lists
+FOR EACH ROW EXECUTE FUNCTION track_change('list');
+
+CREATE TRIGGER todo_items_track_change AFTER INSERT OR UPDATE ON todo_items
+FOR EACH ROW EXECUTE FUNCTION track_change('item');
+
+CREATE FUNCTION track_item_move() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO sync_tombstones (user_id, record_type, record_id)
+  SELECT ul.user_id, 'item', NEW.item_id FROM users_lists ul WHERE ul.list_id = OLD.list_id
+  AND NOT EXISTS (SELECT 1 FROM users_lists nl WHERE nl.list_id = NEW.list_id AND nl.user_id = ul.user_id);
+  INSERT INTO sync_changes (record_type, record_id, change_xid)
+  VALUES ('item', NEW.item_id, pg_current_xact_id())
+  ON CONFLICT (record_type, record_id) DO UPDATE SET change_xid = EXCLUDED.change_xid;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER lists_items_track_move AFTER UPDATE ON lists_items
+FOR EACH ROW EXECUTE FUNCTION track_item_move();
+
+CREATE FUNCTION track_item_delete() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO sync_tombstones (user_id, record_type, record_id)
+  SELECT ul.user_id, 'item', OLD.id FROM lists_items li INNER JOIN users_lists ul ON ul.list_id = li.list_id
+  WHERE li.item_id = OLD.id;
+  DELETE FROM sync_changes WHERE record_type = 'item' AND record_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_items_track_delete BEFORE DELETE ON todo_items
+FOR EACH ROW EXECUTE FUNCTION track_item_delete();
+
+CREATE FUNCTION track_list_delete() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM sync_changes WHERE record_type = 'list' AND record_id = OLD.id;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_lists_track_delete AFTER DELETE ON todo_lists
+FOR EACH ROW EXECUTE FUNCTION track_list_delete();
+
+CREATE FUNCTION track_list_access() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO sync_tombstones (user_id, record_type, record_id) VALUES (OLD.user_id, 'list', OLD.list_id);
+  ELSE
+    INSERT INTO sync_changes (record_type, record_id, change_xid)
+    VALUES ('list', NEW.list_id, pg_current_xact_id())
+    ON CONFLICT (record_type, record_id) DO UPDATE SET change_xid = EXCLUDED.change_xid;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_lists_track_access AFTER INSERT OR DELETE ON users_lists
+FOR EACH ROW EXECUTE FUNCTION track_list_access();
\ No newline at end of file
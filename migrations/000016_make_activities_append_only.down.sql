@@ -0,0 +1,21 @@
+DROP TRIGGER activities_no_truncate ON activities;
+
+DROP TRIGGER activities_append_only ON activities;
+
+CREATE TRIGGER activities_append_only BEFORE UPDATE ON activities
+FOR EACH ROW EXECUTE FUNCTION forbid_activity_update();
+
+ALTER TABLE activities DROP CONSTRAINT activities_undo_of_fkey,
+  ADD CONSTRAINT activities_undo_of_fkey FOREIGN KEY (undo_of) REFERENCES activities (id) ON DELETE CASCADE;
+
+ALTER TABLE activities DROP CONSTRAINT activities_user_id_fkey,
+  ADD CONSTRAINT activities_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+DELETE FROM activities a WHERE NOT EXISTS (SELECT 1 FROM todo_lists tl WHERE tl.id = a.list_id)
+  OR (a.item_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM todo_items ti WHERE ti.id = a.item_id));
+
+ALTER TABLE activities ADD CONSTRAINT activities_item_id_fkey
+  FOREIGN KEY (item_id) REFERENCES todo_items (id) ON DELETE CASCADE;
+
+ALTER TABLE activities ADD CONSTRAINT activities_list_id_fkey
+  FOREIGN KEY (list_id) REFERENCES todo_lists (id) ON DELETE CASCADE;
\ No newline at end of file
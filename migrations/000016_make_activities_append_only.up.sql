@@ -0,0 +1,19 @@
+-- The log outlives the lists and items it describes: they are purged from
+-- the trash, their activity is kept.
+ALTER TABLE activities DROP CONSTRAINT activities_list_id_fkey;
+
+ALTER TABLE activities DROP CONSTRAINT activities_item_id_fkey;
+
+ALTER TABLE activities DROP CONSTRAINT activities_user_id_fkey,
+  ADD CONSTRAINT activities_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT;
+
+ALTER TABLE activities DROP CONSTRAINT activities_undo_of_fkey,
+  ADD CONSTRAINT activities_undo_of_fkey FOREIGN KEY (undo_of) REFERENCES activities (id) ON DELETE RESTRICT;
+
+DROP TRIGGER activities_append_only ON activities;
+
+CREATE TRIGGER activities_append_only BEFORE UPDATE OR DELETE ON activities
+FOR EACH ROW EXECUTE FUNCTION forbid_activity_update();
+
+CREATE TRIGGER activities_no_truncate BEFORE TRUNCATE ON activities
+FOR EACH STATEMENT EXECUTE FUNCTION forbid_activity_update();
\ No newline at end of file
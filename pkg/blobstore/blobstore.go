@@ -0,0 +1,7 @@
+package blobstore
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("blob not found")
+)
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type tokensResponse struct {
+	Tokens struct {
+		AccessToken string
+	} `json:"tokens"`
+}
+
+func (c *Client) SignUp(ctx context.Context, user User) (int, error) {
+	var resp struct {
+		Id int `json:"id"`
+	}
+	err := c.do(ctx, request{method: "POST", path: "/auth/sign-up", body: user}, &resp)
+	return resp.Id, err
+}
+
+// SignIn signs the user in, the following calls are made on their behalf.
+func (c *Client) SignIn(ctx context.Context, username string, password string) error {
+	input := map[string]string{"username": username, "password": password}
+
+	var resp tokensResponse
+	if err := c.do(ctx, request{method: "POST", path: "/auth/sign-in", body: input}, &resp); err != nil {
+		return err
+	}
+	c.setToken(resp.Tokens.AccessToken)
+	return nil
+}
+
+// Refresh exchanges the refresh token cookie for new tokens. Calls refresh
+// expired access tokens themselves, so it is rarely needed directly.
+func (c *Client) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refresh(ctx)
+}
+
+// refreshExpired refreshes the expired access token, unless another call
+// has replaced it meanwhile.
+func (c *Client) refreshExpired(ctx context.Context, expired string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if c.token() != expired {
+		return nil
+	}
+	return c.refresh(ctx)
+}
+
+func (c *Client) refresh(ctx context.Context) error {
+	var resp tokensResponse
+	if err := c.do(ctx, request{method: "POST", path: "/auth/refresh"}, &resp); err != nil {
+		return err
+	}
+	c.setToken(resp.Tokens.AccessToken)
+	return nil
+}
+
+// Logout ends the session of the client.
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, request{method: "DELETE", path: "/auth/logout"}, nil); err != nil {
+		return err
+	}
+	c.setToken("")
+	return nil
+}
+
+// LogoutAll ends all sessions of the user.
+func (c *Client) LogoutAll(ctx context.Context) error {
+	if err := c.do(ctx, request{method: "DELETE", path: "/auth/logout-all"}, nil); err != nil {
+		return err
+	}
+	c.setToken("")
+	return nil
+}
+
+// refreshCookie is the cookie the server keeps the refresh token in.
+const refreshCookie = "refreshToken"
+
+// Tokens returns the tokens of the session, SetTokens resumes it in another
+// client.
+func (c *Client) Tokens() (accessToken string, refreshToken string) {
+	if u, err := url.Parse(c.baseURL + "/auth/refresh"); err == nil {
+		for _, cookie := range c.httpClient.Jar.Cookies(u) {
+			if cookie.Name == refreshCookie {
+				refreshToken = cookie.Value
+			}
+		}
+	}
+	return c.token(), refreshToken
+}
+
+func (c *Client) SetTokens(accessToken string, refreshToken string) {
+	c.setToken(accessToken)
+	if u, err := url.Parse(c.baseURL + "/auth/"); err == nil {
+		c.httpClient.Jar.SetCookies(u, []*http.Cookie{{Name: refreshCookie, Value: refreshToken, Path: "/auth"}})
+	}
+}
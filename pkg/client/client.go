@@ -0,0 +1,138 @@
+// Package client is a typed client of the todo REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"sync"
+)
+
+// Client calls the API on behalf of one user. The refresh token is kept in
+// the cookie jar the way browsers keep it, and the access token is refreshed
+// when the server reports it expired.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	// refreshMu lets one refresh run at a time. The server rotates the
+	// refresh token, a second refresh with the old one would fail.
+	refreshMu sync.Mutex
+}
+
+// New returns a client of the API at baseURL. A nil httpClient means
+// http.DefaultClient, a client without a cookie jar is used with one of its
+// own.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	withJar := *httpClient
+	if withJar.Jar == nil {
+		// cookiejar.New never fails without options.
+		withJar.Jar, _ = cookiejar.New(nil)
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &withJar,
+	}
+}
+
+// request describes a call to the API.
+type request struct {
+	method string
+	path   string
+	header http.Header
+	body   interface{}
+}
+
+// do sends a request and decodes the response into out. Requests to the api
+// routes are retried once with a refreshed access token when it expired.
+// Concurrent calls that find the same token expired refresh it once.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return err
+		}
+	}
+
+	token := c.token()
+	err := c.send(ctx, req, body, token, out)
+	if errors.Is(err, ErrTokenExpired) && strings.HasPrefix(req.path, "/api/") {
+		if err = c.refreshExpired(ctx, token); err != nil {
+			return err
+		}
+		err = c.send(ctx, req, body, c.token(), out)
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, req request, body []byte, token string, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, reader)
+	if err != nil {
+		return err
+	}
+
+	for name, values := range req.header {
+		httpReq.Header[name] = values
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+func (c *Client) token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken
+}
+
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = token
+}
+
+// ifMatch makes a request conditional on the version of a record, a nil
+// version makes it unconditional.
+func ifMatch(version *int) http.Header {
+	if version == nil {
+		return nil
+	}
+	return http.Header{"If-Match": {fmt.Sprintf(`"%d"`, *version)}}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/IvanMeln1k/go-todo-app/internal/domain"
+	"github.com/IvanMeln1k/go-todo-app/internal/handler"
+	"github.com/IvanMeln1k/go-todo-app/internal/service"
+	mock_service "github.com/IvanMeln1k/go-todo-app/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type mocks struct {
+	auth     *mock_service.MockAuthorization
+	todoList *mock_service.MockTodoList
+	todoItem *mock_service.MockTodoItem
+}
+
+// newTestClient returns a client of a server with mocked services, signed
+// in as user 1 with the access token "access".
+func newTestClient(t *testing.T) (*Client, mocks) {
+	c := gomock.NewController(t)
+	m := mocks{
+		auth:     mock_service.NewMockAuthorization(c),
+		todoList: mock_service.NewMockTodoList(c),
+		todoItem: mock_service.NewMockTodoItem(c),
+	}
+
+	services := &service.Service{Authorization: m.auth, TodoList: m.todoList, TodoItem: m.todoItem}
+	server := httptest.NewServer(handler.NewHandler(services).InitRoutes())
+	t.Cleanup(server.Close)
+
+	client := New(server.URL, nil)
+	m.auth.EXPECT().SignIn(gomock.Any(), "user", "secret").
+		Return(service.Tokens{AccessToken: "access", RefreshToken: "refresh"}, nil)
+	assert.NoError(t, client.SignIn(context.Background(), "user", "secret"))
+
+	return client, m
+}
+
+func TestClient_signInAndGetList(t *testing.T) {
+	client, m := newTestClient(t)
+
+	todoList := domain.TodoList{Id: 2, Title: "list", Version: 3}
+	m.auth.EXPECT().ParseToken("access").Return(1, nil)
+	m.todoList.EXPECT().GetById(1, 2).Return(todoList, nil)
+
+	got, err := client.GetList(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, TodoList{Id: 2, Title: "list", Version: 3}, got)
+}
+
+func TestClient_refreshesExpiredToken(t *testing.T) {
+	client, m := newTestClient(t)
+
+	todoItem := domain.TodoItem{Id: 5, Title: "item", Done: true, Version: 2}
+	gomock.InOrder(
+		m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired),
+		m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+			Return(service.Tokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil),
+		m.auth.EXPECT().ParseToken("access2").Return(1, nil),
+	)
+	m.todoItem.EXPECT().GetAll(1, 2).Return([]domain.TodoItem{todoItem}, nil)
+
+	got, err := client.GetItems(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []TodoItem{{Id: 5, Title: "item", Done: true, Version: 2}}, got)
+}
+
+func TestClient_errors(t *testing.T) {
+	client, m := newTestClient(t)
+	m.auth.EXPECT().ParseToken("access").Return(1, nil).AnyTimes()
+
+	m.todoList.EXPECT().GetById(1, 2).Return(domain.TodoList{}, domain.ErrListNotFound)
+	_, err := client.GetList(context.Background(), 2)
+	assert.True(t, errors.Is(err, ErrListNotFound))
+	var clientErr *Error
+	assert.True(t, errors.As(err, &clientErr))
+	assert.Equal(t, 404, clientErr.StatusCode)
+
+	version := 3
+	m.todoItem.EXPECT().Replace(1, 4, domain.ReplaceTodoItem{Title: "new", Version: &version}).
+		Return(domain.TodoItem{}, service.ErrVersionMismatch)
+	_, err = client.ReplaceItem(context.Background(), 4, ReplaceTodoItem{Title: "new", Version: &version})
+	assert.True(t, errors.Is(err, ErrVersionMismatch))
+
+	_, err = client.CreateItem(context.Background(), 2, TodoItem{})
+	assert.True(t, errors.As(err, &clientErr))
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.Equal(t, []FieldError{
+		{Field: "title", Rule: "required", Message: "title is a required field"},
+	}, clientErr.Fields)
+}
+
+func TestClient_authErrors(t *testing.T) {
+	testTable := []struct {
+		name          string
+		mockBehavior  func(m mocks)
+		call          func(client *Client) error
+		expectedError error
+	}{
+		{
+			name: "invalid credentials",
+			mockBehavior: func(m mocks) {
+				m.auth.EXPECT().SignIn(gomock.Any(), "user", "wrong").Return(service.Tokens{},
+					service.ErrInvalidUsernameOrPassowrd)
+			},
+			call: func(client *Client) error {
+				return client.SignIn(context.Background(), "user", "wrong")
+			},
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name: "invalid token",
+			mockBehavior: func(m mocks) {
+				m.auth.EXPECT().ParseToken("access").Return(0, service.ErrInvalidTokenSignature)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "token expired after a refresh",
+			mockBehavior: func(m mocks) {
+				m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired)
+				m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+					Return(service.Tokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil)
+				m.auth.EXPECT().ParseToken("access2").Return(0, service.ErrTokenExpired)
+			},
+			expectedError: ErrTokenExpired,
+		},
+		{
+			name: "session expired",
+			mockBehavior: func(m mocks) {
+				m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired)
+				m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+					Return(service.Tokens{}, service.ErrSessionExpiredOrInvalid)
+			},
+			expectedError: ErrSessionExpired,
+		},
+		{
+			name: "invalid session",
+			mockBehavior: func(m mocks) {
+				m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired)
+				m.auth.EXPECT().Refresh(gomock.Any(), "refresh").Return(service.Tokens{}, service.ErrInvalidSession)
+			},
+			expectedError: ErrInvalidSession,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, m := newTestClient(t)
+			testCase.mockBehavior(m)
+
+			var err error
+			if testCase.call != nil {
+				err = testCase.call(client)
+			} else {
+				_, err = client.GetList(context.Background(), 2)
+			}
+
+			assert.True(t, errors.Is(err, testCase.expectedError), "got %v", err)
+			assert.True(t, errors.Is(err, ErrUnauthorized))
+			assert.False(t, errors.Is(err, ErrListNotFound))
+		})
+	}
+}
+
+func TestClient_logout(t *testing.T) {
+	client, m := newTestClient(t)
+
+	m.auth.EXPECT().Logout(gomock.Any(), "refresh").Return(nil)
+	assert.NoError(t, client.Logout(context.Background()))
+
+	_, err := client.GetList(context.Background(), 2)
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
+
+func TestClient_resumesSession(t *testing.T) {
+	client, m := newTestClient(t)
+
+	accessToken, refreshToken := client.Tokens()
+	assert.Equal(t, "access", accessToken)
+	assert.Equal(t, "refresh", refreshToken)
+
+	resumed := New(client.baseURL, nil)
+	resumed.SetTokens("expired", refreshToken)
+
+	m.auth.EXPECT().ParseToken("expired").Return(0, service.ErrTokenExpired)
+	m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+		Return(service.Tokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil)
+	m.auth.EXPECT().ParseToken("access2").Return(1, nil)
+	m.todoList.EXPECT().Archive(1, 2).Return(domain.TodoList{Id: 2, Archived: true}, nil)
+
+	todoList, err := resumed.ArchiveList(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.True(t, todoList.Archived)
+
+	accessToken, refreshToken = resumed.Tokens()
+	assert.Equal(t, "access2", accessToken)
+	assert.Equal(t, "refresh2", refreshToken)
+}
+
+func TestClient_refreshesOnce(t *testing.T) {
+	client, m := newTestClient(t)
+
+	// All calls find the token expired, the first one to refresh it
+	// replaces it for the others.
+	m.auth.EXPECT().ParseToken("access").Return(0, service.ErrTokenExpired).AnyTimes()
+	m.auth.EXPECT().Refresh(gomock.Any(), "refresh").
+		Return(service.Tokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil)
+	m.auth.EXPECT().ParseToken("access2").Return(1, nil).AnyTimes()
+	m.todoList.EXPECT().GetById(1, 2).Return(domain.TodoList{Id: 2}, nil).Times(5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetList(context.Background(), 2)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
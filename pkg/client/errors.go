@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Error is an error response of the API. Code is the stable code of the
+// server error, errors.Is matches errors by it, e.g.
+// errors.Is(err, client.ErrListNotFound). ErrUnauthorized matches any
+// 401 response instead.
+type Error struct {
+	StatusCode int
+	Code       string
+	Detail     string
+	// Fields lists the invalid fields of a validation error.
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%d %s)", e.Detail, e.StatusCode, e.Code)
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t == ErrUnauthorized {
+		return e.StatusCode == http.StatusUnauthorized
+	}
+	return t.Code == e.Code
+}
+
+var (
+	// ErrUnauthorized is the error of a call without a valid session, it
+	// matches all of the errors below.
+	ErrUnauthorized = &Error{StatusCode: http.StatusUnauthorized, Code: "unauthorized"}
+	// ErrInvalidCredentials is the error of a sign in with a wrong username
+	// or password.
+	ErrInvalidCredentials = &Error{Code: "invalid_credentials"}
+	// ErrTokenExpired is the error of an expired access token, the client
+	// refreshes it and retries the call.
+	ErrTokenExpired = &Error{Code: "token_expired"}
+	ErrInvalidToken = &Error{Code: "invalid_token"}
+	// ErrSessionExpired and ErrInvalidSession are the errors of a refresh
+	// token that can't be used any more, the user has to sign in again.
+	ErrSessionExpired = &Error{Code: "session_expired"}
+	ErrInvalidSession = &Error{Code: "invalid_session"}
+)
+
+var (
+	// ErrValidation is the error of invalid input, Fields of the returned
+	// error lists the invalid fields.
+	ErrValidation   = &Error{Code: "validation_failed"}
+	ErrListNotFound = &Error{Code: "list_not_found"}
+	ErrItemNotFound = &Error{Code: "item_not_found"}
+	// ErrVersionMismatch is the error of a conditional change of a record
+	// that has changed since.
+	ErrVersionMismatch = &Error{Code: "version_mismatch"}
+)
+
+// decodeError reads a problem details body. Responses without one, like
+// those of proxies, are reported by their status.
+func decodeError(resp *http.Response) error {
+	var problem struct {
+		Code   string       `json:"code"`
+		Detail string       `json:"detail"`
+		Errors []FieldError `json:"errors"`
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || json.Unmarshal(body, &problem) != nil || problem.Code == "" {
+		return &Error{StatusCode: resp.StatusCode, Code: "http_error", Detail: http.StatusText(resp.StatusCode)}
+	}
+
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Code:       problem.Code,
+		Detail:     problem.Detail,
+		Fields:     problem.Errors,
+	}
+}
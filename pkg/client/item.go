@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+type todoItemResponse struct {
+	TodoItem TodoItem `json:"todoItem"`
+}
+
+func (c *Client) CreateItem(ctx context.Context, todoListId int, todoItem TodoItem) (int, error) {
+	var id int
+	err := c.do(ctx, request{
+		method: "POST",
+		path:   fmt.Sprintf("/api/lists/%d/items/", todoListId),
+		body:   todoItem,
+	}, &id)
+	return id, err
+}
+
+func (c *Client) GetItems(ctx context.Context, todoListId int) ([]TodoItem, error) {
+	var resp struct {
+		TodoItems []TodoItem `json:"todoItems"`
+	}
+	err := c.do(ctx, request{method: "GET", path: fmt.Sprintf("/api/lists/%d/items/", todoListId)}, &resp)
+	return resp.TodoItems, err
+}
+
+func (c *Client) GetItem(ctx context.Context, todoItemId int) (TodoItem, error) {
+	var resp todoItemResponse
+	err := c.do(ctx, request{method: "GET", path: fmt.Sprintf("/api/items/%d", todoItemId)}, &resp)
+	return resp.TodoItem, err
+}
+
+// ReplaceItem replaces the editable fields of an item. A set Version makes it
+// fail with ErrVersionMismatch if the item has changed since.
+func (c *Client) ReplaceItem(ctx context.Context, todoItemId int, input ReplaceTodoItem) (TodoItem, error) {
+	var resp todoItemResponse
+	err := c.do(ctx, request{
+		method: "PUT",
+		path:   fmt.Sprintf("/api/items/%d", todoItemId),
+		header: ifMatch(input.Version),
+		body:   input,
+	}, &resp)
+	return resp.TodoItem, err
+}
+
+// DeleteItem moves an item to the trash, on the same condition as
+// ReplaceItem.
+func (c *Client) DeleteItem(ctx context.Context, todoItemId int, version *int) error {
+	return c.do(ctx, request{
+		method: "DELETE",
+		path:   fmt.Sprintf("/api/items/%d", todoItemId),
+		header: ifMatch(version),
+	}, nil)
+}
+
+func (c *Client) MoveItem(ctx context.Context, todoItemId int, todoListId int) (TodoItem, error) {
+	var resp todoItemResponse
+	err := c.do(ctx, request{
+		method: "POST",
+		path:   fmt.Sprintf("/api/items/%d/move", todoItemId),
+		body:   todoItemDestination{ListId: todoListId},
+	}, &resp)
+	return resp.TodoItem, err
+}
+
+// CopyItem copies an item to a list and returns the id of the copy.
+func (c *Client) CopyItem(ctx context.Context, todoItemId int, todoListId int) (int, error) {
+	var id int
+	err := c.do(ctx, request{
+		method: "POST",
+		path:   fmt.Sprintf("/api/items/%d/copy", todoItemId),
+		body:   todoItemDestination{ListId: todoListId},
+	}, &id)
+	return id, err
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type todoListResponse struct {
+	TodoList TodoList `json:"todoList"`
+}
+
+func (c *Client) CreateList(ctx context.Context, todoList TodoList) (int, error) {
+	var id int
+	err := c.do(ctx, request{method: "POST", path: "/api/lists/", body: todoList}, &id)
+	return id, err
+}
+
+// GetLists returns the active lists of the user, or the archived ones.
+func (c *Client) GetLists(ctx context.Context, archived bool) ([]TodoList, error) {
+	var resp struct {
+		TodoLists []TodoList `json:"todoLists"`
+	}
+	path := "/api/lists/?archived=" + strconv.FormatBool(archived)
+	err := c.do(ctx, request{method: "GET", path: path}, &resp)
+	return resp.TodoLists, err
+}
+
+func (c *Client) GetList(ctx context.Context, todoListId int) (TodoList, error) {
+	var resp todoListResponse
+	err := c.do(ctx, request{method: "GET", path: fmt.Sprintf("/api/lists/%d", todoListId)}, &resp)
+	return resp.TodoList, err
+}
+
+// ReplaceList replaces the editable fields of a list. A set Version makes it
+// fail with ErrVersionMismatch if the list has changed since.
+func (c *Client) ReplaceList(ctx context.Context, todoListId int, input ReplaceTodoList) (TodoList, error) {
+	var resp todoListResponse
+	err := c.do(ctx, request{
+		method: "PUT",
+		path:   fmt.Sprintf("/api/lists/%d", todoListId),
+		header: ifMatch(input.Version),
+		body:   input,
+	}, &resp)
+	return resp.TodoList, err
+}
+
+// DeleteList moves a list to the trash, on the same condition as ReplaceList.
+func (c *Client) DeleteList(ctx context.Context, todoListId int, version *int) error {
+	return c.do(ctx, request{
+		method: "DELETE",
+		path:   fmt.Sprintf("/api/lists/%d", todoListId),
+		header: ifMatch(version),
+	}, nil)
+}
+
+func (c *Client) ArchiveList(ctx context.Context, todoListId int) (TodoList, error) {
+	return c.setListFlag(ctx, "POST", todoListId, "archive")
+}
+
+func (c *Client) UnarchiveList(ctx context.Context, todoListId int) (TodoList, error) {
+	return c.setListFlag(ctx, "DELETE", todoListId, "archive")
+}
+
+func (c *Client) PinList(ctx context.Context, todoListId int) (TodoList, error) {
+	return c.setListFlag(ctx, "POST", todoListId, "pin")
+}
+
+func (c *Client) UnpinList(ctx context.Context, todoListId int) (TodoList, error) {
+	return c.setListFlag(ctx, "DELETE", todoListId, "pin")
+}
+
+func (c *Client) setListFlag(ctx context.Context, method string, todoListId int, flag string) (TodoList, error) {
+	var resp todoListResponse
+	err := c.do(ctx, request{method: method, path: fmt.Sprintf("/api/lists/%d/%s", todoListId, flag)}, &resp)
+	return resp.TodoList, err
+}
@@ -0,0 +1,84 @@
+package client
+
+import "time"
+
+// The types below mirror the JSON of the API. The client has its own copies,
+// so programs using it do not depend on the server's internal packages.
+
+type User struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type TodoList struct {
+	Id          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Archived    bool       `json:"archived"`
+	Pinned      bool       `json:"pinned"`
+	Version     int        `json:"version"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	ClientId    *string    `json:"clientId,omitempty"`
+}
+
+// ReplaceTodoList holds the editable fields of a list, ReplaceList replaces
+// all of them.
+type ReplaceTodoList struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	// Version makes the replacement conditional on the current version of
+	// the list, it is sent in the If-Match header.
+	Version *int `json:"-"`
+}
+
+func (l TodoList) Replacement() ReplaceTodoList {
+	return ReplaceTodoList{
+		Title:       l.Title,
+		Description: l.Description,
+	}
+}
+
+type TodoItem struct {
+	Id          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Done        bool       `json:"done"`
+	StatusId    *int       `json:"statusId"`
+	Version     int        `json:"version"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	ClientId    *string    `json:"clientId,omitempty"`
+}
+
+// ReplaceTodoItem holds the editable fields of an item, ReplaceItem replaces
+// all of them.
+type ReplaceTodoItem struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Done        bool    `json:"done"`
+	// Version makes the replacement conditional on the current version of
+	// the item, it is sent in the If-Match header.
+	Version *int `json:"-"`
+}
+
+func (i TodoItem) Replacement() ReplaceTodoItem {
+	return ReplaceTodoItem{
+		Title:       i.Title,
+		Description: i.Description,
+		Done:        i.Done,
+	}
+}
+
+// FieldError describes an invalid field of a validation error. Field is the
+// JSON path of the field and Rule the rule it broke, with its parameter if it
+// has one.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+type todoItemDestination struct {
+	ListId int `json:"listId"`
+}
@@ -0,0 +1,27 @@
+// Package eventbus carries events from the services that record them to the
+// ones that react to them. Delivery is at least once: a handler that fails
+// gets the message again, and handlers must tolerate duplicates.
+package eventbus
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrSubscribed = errors.New("group is already subscribed")
+)
+
+// Message is an event published on a bus. Key names the aggregate the event
+// belongs to, messages of a key reach a group in the order they were
+// published.
+type Message struct {
+	Id      string
+	Type    string
+	Key     string
+	Payload []byte
+}
+
+// Handler handles a message. A message whose handler returns an error is
+// delivered again, and the messages after it wait for it.
+type Handler func(ctx context.Context, message Message) error
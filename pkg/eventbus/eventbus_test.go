@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := NewMemoryBus()
+
+	var received []Message
+	failing := errors.New("handler failed")
+	subscribed := make(chan error, 2)
+	go func() {
+		subscribed <- bus.Subscribe(ctx, "streams", func(ctx context.Context, message Message) error {
+			received = append(received, message)
+			if message.Id == "2" {
+				return failing
+			}
+			return nil
+		})
+	}()
+	require.Eventually(t, func() bool {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+		return len(bus.handlers) == 1
+	}, time.Second, time.Millisecond)
+
+	assert.ErrorIs(t, bus.Subscribe(ctx, "streams", nil), ErrSubscribed)
+
+	assert.NoError(t, bus.Publish(ctx, Message{Id: "1", Key: "item:1"}))
+	assert.ErrorIs(t, bus.Publish(ctx, Message{Id: "2", Key: "item:1"}), failing)
+	assert.Equal(t, []Message{{Id: "1", Key: "item:1"}, {Id: "2", Key: "item:1"}}, received)
+
+	cancel()
+	assert.ErrorIs(t, <-subscribed, context.Canceled)
+	assert.NoError(t, bus.Publish(context.Background(), Message{Id: "3"}))
+	assert.Len(t, received, 2)
+}
+
+func TestCompareIds(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1700000000000-0", b: "1700000000000-0", expected: 0},
+		{a: "1700000000000-1", b: "1700000000000-0", expected: 1},
+		{a: "1700000000000-9", b: "1700000000000-10", expected: -1},
+		{a: "999-5", b: "1000-0", expected: -1},
+		{a: "0-0", b: "1-0", expected: -1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, compareIds(test.a, test.b), "%s vs %s", test.a, test.b)
+	}
+}
@@ -0,0 +1,53 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBus delivers messages to the groups subscribed in the same process.
+// Publish returns after every group handled the message, so the publisher
+// retries the ones that failed.
+type MemoryBus struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: map[string]Handler{}}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, message Message) error {
+	b.mu.Lock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe handles the messages published to the group until ctx is done.
+// A group has a single subscriber.
+func (b *MemoryBus) Subscribe(ctx context.Context, group string, handler Handler) error {
+	b.mu.Lock()
+	if _, ok := b.handlers[group]; ok {
+		b.mu.Unlock()
+		return ErrSubscribed
+	}
+	b.handlers[group] = handler
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, group)
+	b.mu.Unlock()
+	return ctx.Err()
+}
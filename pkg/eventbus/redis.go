@@ -0,0 +1,236 @@
+package eventbus
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	readCount  = 100
+	readBlock  = 5 * time.Second
+	retryDelay = time.Second
+	// claimIdle is how long a message stays pending with a consumer before
+	// another consumer of the group takes it over.
+	claimIdle = time.Minute
+	// trimInterval is how often a subscriber trims the messages all groups
+	// are done with.
+	trimInterval = time.Minute
+)
+
+// RedisBus publishes messages to a Redis stream and reads them through
+// consumer groups, so they survive restarts of the subscribers. Messages of
+// a key stay in order within a group as long as the group has one consumer.
+type RedisBus struct {
+	rdb      *redis.Client
+	stream   string
+	consumer string
+}
+
+// NewRedisBus returns a bus on the stream. The consumer names this instance
+// in its groups and must stay the same across restarts, so the instance
+// reads the messages it left pending. It defaults to the host name.
+func NewRedisBus(rdb *redis.Client, stream string, consumer string) *RedisBus {
+	if consumer == "" {
+		consumer, _ = os.Hostname()
+	}
+	return &RedisBus{
+		rdb:      rdb,
+		stream:   stream,
+		consumer: consumer,
+	}
+}
+
+// Publish adds the message to the stream. The stream is not capped, messages
+// are trimmed by the subscribers once every group is done with them.
+func (b *RedisBus) Publish(ctx context.Context, message Message) error {
+	return b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream,
+		Values: map[string]interface{}{
+			"id":      message.Id,
+			"type":    message.Type,
+			"key":     message.Key,
+			"payload": message.Payload,
+		},
+	}).Err()
+}
+
+// Subscribe handles the messages of the group until ctx is done. Messages
+// the group read but did not acknowledge before are handled first: the ones
+// of this consumer and the ones left idle by others, like a subscriber that
+// crashed and did not come back.
+func (b *RedisBus) Subscribe(ctx context.Context, group string, handler Handler) error {
+	err := b.rdb.XGroupCreateMkStream(ctx, b.stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	if err = b.claim(ctx, group); err != nil {
+		return err
+	}
+
+	// "0" reads the pending messages of the consumer, ">" the new ones.
+	start := "0"
+	var trimmedAt time.Time
+	for {
+		if time.Since(trimmedAt) > trimInterval {
+			// A failed trim is tried again on the next interval.
+			b.trim(ctx)
+			trimmedAt = time.Now()
+		}
+
+		streams, err := b.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumer,
+			Streams:  []string{b.stream, start},
+			Count:    readCount,
+			Block:    readBlock,
+		}).Result()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if err = wait(ctx, retryDelay); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var messages []redis.XMessage
+		if len(streams) > 0 {
+			messages = streams[0].Messages
+		}
+		if start == "0" && len(messages) == 0 {
+			start = ">"
+			continue
+		}
+
+		for _, xMessage := range messages {
+			if err = handler(ctx, decode(xMessage)); err == nil {
+				err = b.rdb.XAck(ctx, b.stream, group, xMessage.ID).Err()
+			}
+			if err != nil {
+				// The message stays pending and is read again before
+				// anything newer.
+				start = "0"
+				if err = wait(ctx, retryDelay); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+}
+
+// claim takes over the messages of the group that other consumers left
+// pending for longer than claimIdle.
+func (b *RedisBus) claim(ctx context.Context, group string) error {
+	start := "0-0"
+	for {
+		_, next, err := b.rdb.XAutoClaimJustID(ctx, &redis.XAutoClaimArgs{
+			Stream:   b.stream,
+			Group:    group,
+			Consumer: b.consumer,
+			MinIdle:  claimIdle,
+			Start:    start,
+			Count:    readCount,
+		}).Result()
+		if err != nil {
+			return err
+		}
+		if next == "0-0" {
+			return nil
+		}
+		start = next
+	}
+}
+
+// trim removes the messages below the oldest one a group still needs: its
+// oldest pending message, or the first one it has not read yet. Messages no
+// group is done with are never trimmed, however far behind the group is.
+func (b *RedisBus) trim(ctx context.Context) error {
+	groups, err := b.rdb.XInfoGroups(ctx, b.stream).Result()
+	if err != nil {
+		return err
+	}
+
+	var minId string
+	for _, group := range groups {
+		keep := group.LastDeliveredID
+		if group.Pending > 0 {
+			pending, err := b.rdb.XPending(ctx, b.stream, group.Name).Result()
+			if err != nil {
+				return err
+			}
+			keep = pending.Lower
+		}
+		if minId == "" || compareIds(keep, minId) < 0 {
+			minId = keep
+		}
+	}
+	if minId == "" {
+		return nil
+	}
+
+	// MINID keeps the messages from minId on. A last delivered id is kept
+	// along with the unread ones, which costs a single message.
+	return b.rdb.XTrimMinIDApprox(ctx, b.stream, minId, 0).Err()
+}
+
+// compareIds orders stream ids, which are a millisecond time and a sequence
+// number joined by a dash.
+func compareIds(a string, b string) int {
+	aTime, aSeq := splitId(a)
+	bTime, bSeq := splitId(b)
+	switch {
+	case aTime != bTime:
+		if aTime < bTime {
+			return -1
+		}
+		return 1
+	case aSeq != bSeq:
+		if aSeq < bSeq {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func splitId(id string) (uint64, uint64) {
+	timePart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(timePart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
+
+func decode(xMessage redis.XMessage) Message {
+	field := func(name string) string {
+		value, _ := xMessage.Values[name].(string)
+		return value
+	}
+	return Message{
+		Id:      field("id"),
+		Type:    field("type"),
+		Key:     field("key"),
+		Payload: []byte(field("payload")),
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,276 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IvanMeln1k/go-todo-app/pkg/validate"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ContentMediaType     string             `json:"contentMediaType,omitempty"`
+}
+
+// Object is a schema of an object with the given properties, all of them
+// required.
+func Object(properties map[string]*Schema) *Schema {
+	schema := &Schema{Type: "object", Properties: properties}
+	for name := range properties {
+		schema.Required = append(schema.Required, name)
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// Binary is a schema of raw content, like an uploaded file.
+func Binary() *Schema {
+	return &Schema{Type: "string", ContentMediaType: "application/octet-stream"}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Generator generates schemas from Go values. Named structs become
+// components referenced by the schemas.
+type Generator struct {
+	schemas map[string]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{schemas: map[string]*Schema{}}
+}
+
+// Schemas returns the components generated so far.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// Schema returns the schema of the JSON encoding of v. A map[string]interface{}
+// is described by the values it holds, so a response can be documented the
+// way handlers write it.
+func (g *Generator) Schema(v interface{}) *Schema {
+	if object, ok := v.(map[string]interface{}); ok {
+		properties := make(map[string]*Schema, len(object))
+		for name, value := range object {
+			properties[name] = g.Schema(value)
+		}
+		return Object(properties)
+	}
+	return g.typeSchema(reflect.TypeOf(v))
+}
+
+func (g *Generator) typeSchema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return &Schema{}
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	if name := t.Name(); name != "" {
+		// Unexported types are documented too, their components are named
+		// like exported ones.
+		name = strings.ToUpper(name[:1]) + name[1:]
+		ref := &Schema{Ref: "#/components/schemas/" + name}
+		if _, ok := g.schemas[name]; ok {
+			return ref
+		}
+		// The component is registered before its fields are generated, so a
+		// type can refer to itself.
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.objectSchema(t)
+		return ref
+	}
+	return g.objectSchema(t)
+}
+
+func (g *Generator) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// The fields of an embedded struct are visible fields of t.
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if len(field.Index) > 1 && embeddedByName(t, field.Index) {
+			continue
+		}
+
+		property := g.typeSchema(field.Type)
+		if applyRules(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// jsonName returns the name a field is encoded with, empty if it has none
+// set.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// embeddedByName reports whether a promoted field belongs to an embedded
+// struct that has a JSON name, which encodes it as a nested object instead.
+func embeddedByName(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if jsonName(t.FieldByIndex(index[:i])) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable allows null in place of the value of a schema.
+func nullable(schema *Schema) *Schema {
+	switch t := schema.Type.(type) {
+	case string:
+		schema.Type = []string{t, "null"}
+		return schema
+	case nil:
+		if schema.Ref == "" {
+			return schema
+		}
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
+
+func baseType(schema *Schema) string {
+	switch t := schema.Type.(type) {
+	case string:
+		return t
+	case []string:
+		return t[0]
+	}
+	return ""
+}
+
+// applyRules adds the constraints of validator rules to a schema and reports
+// whether the field is required.
+func applyRules(schema *Schema, tag string) bool {
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if schema.Items != nil {
+				applyRules(schema.Items, strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "title":
+			schema.MinLength, schema.MaxLength = intPtr(1), intPtr(validate.MaxVarcharLength)
+		case "varchar":
+			schema.MaxLength = intPtr(validate.MaxVarcharLength)
+		case "httpurl":
+			schema.Format, schema.MaxLength = "uri", intPtr(validate.MaxVarcharLength)
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max":
+			applyBound(schema, name == "min", param)
+		}
+	}
+	return required
+}
+
+func applyBound(schema *Schema, lower bool, param string) {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+
+	switch baseType(schema) {
+	case "string":
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		bound := float64(n)
+		if lower {
+			schema.Minimum = &bound
+		} else {
+			schema.Maximum = &bound
+		}
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuthor struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type testNote struct {
+	Id        int        `json:"id"`
+	Title     string     `json:"title" validate:"required,title"`
+	Body      *string    `json:"body" validate:"varchar"`
+	Kind      string     `json:"kind" validate:"oneof=text list"`
+	Tags      []string   `json:"tags,omitempty" validate:"max=3,dive,min=1"`
+	Secret    string     `json:"-"`
+	CreatedAt *time.Time `json:"createdAt"`
+	testAuthor
+	Done bool
+}
+
+func TestGenerator_Schema(t *testing.T) {
+	g := NewGenerator()
+
+	schema, err := json.Marshal(g.Schema(map[string]interface{}{"notes": []testNote{}}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","required":["notes"],"properties":{
+		"notes":{"type":"array","items":{"$ref":"#/components/schemas/TestNote"}}}}`, string(schema))
+
+	note, err := json.Marshal(g.Schemas()["TestNote"])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","required":["title"],"properties":{
+		"id":{"type":"integer","format":"int32"},
+		"title":{"type":"string","minLength":1,"maxLength":255},
+		"body":{"type":["string","null"],"maxLength":255},
+		"kind":{"type":"string","enum":["text","list"]},
+		"tags":{"type":"array","maxItems":3,"items":{"type":"string","minLength":1}},
+		"createdAt":{"type":["string","null"],"format":"date-time"},
+		"name":{"type":"string"},
+		"Done":{"type":"boolean"}}}`, string(note))
+}
+
+func TestPathTemplate(t *testing.T) {
+	template, names := PathTemplate("/api/lists/:id/items/:itemId")
+	assert.Equal(t, "/api/lists/{id}/items/{itemId}", template)
+	assert.Equal(t, []string{"id", "itemId"}, names)
+}
@@ -0,0 +1,352 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	op       string
+	path     []string
+	from     []string
+	value    interface{}
+	hasValue bool
+}
+
+// ApplyJSONPatch applies a JSON Patch to doc. Operations are applied in
+// order and the whole patch fails if any of them does, including a failed
+// test operation.
+func ApplyJSONPatch(doc []byte, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := parseOperations(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		target, err = operation.apply(target)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func parseOperations(patch []byte) ([]operation, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+	}
+
+	operations := make([]operation, 0, len(raw))
+	for i, fields := range raw {
+		var op operation
+		if err := op.parse(fields); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %s", ErrInvalidPatch, i, err.Error())
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+func (o *operation) parse(fields map[string]json.RawMessage) error {
+	if err := json.Unmarshal(fields["op"], &o.op); err != nil {
+		return fmt.Errorf("bad op")
+	}
+
+	var err error
+	if o.path, err = parsePointerField(fields, "path"); err != nil {
+		return err
+	}
+
+	switch o.op {
+	case "add", "replace", "test":
+		rawValue, ok := fields["value"]
+		if !ok {
+			return fmt.Errorf("%s has no value", o.op)
+		}
+		if o.value, err = decode(rawValue); err != nil {
+			return err
+		}
+		o.hasValue = true
+	case "move", "copy":
+		if o.from, err = parsePointerField(fields, "from"); err != nil {
+			return err
+		}
+	case "remove":
+	default:
+		return fmt.Errorf("unknown op %q", o.op)
+	}
+	return nil
+}
+
+func parsePointerField(fields map[string]json.RawMessage, name string) ([]string, error) {
+	var pointer string
+	if err := json.Unmarshal(fields[name], &pointer); err != nil {
+		return nil, fmt.Errorf("bad %s", name)
+	}
+	return parsePointer(pointer)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer %q does not start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func (o *operation) apply(doc interface{}) (interface{}, error) {
+	switch o.op {
+	case "add":
+		return add(doc, o.path, o.value)
+	case "remove":
+		doc, _, err := remove(doc, o.path)
+		return doc, err
+	case "replace":
+		if _, err := get(doc, o.path); err != nil {
+			return nil, err
+		}
+		if len(o.path) == 0 {
+			return o.value, nil
+		}
+		doc, _, err := remove(doc, o.path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, o.path, o.value)
+	case "move":
+		if isProperPrefix(o.from, o.path) {
+			return nil, fmt.Errorf("%w: can't move a value into itself", ErrInvalidPatch)
+		}
+		doc, value, err := remove(doc, o.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, o.path, value)
+	case "copy":
+		value, err := get(doc, o.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, o.path, deepCopy(value))
+	case "test":
+		value, err := get(doc, o.path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, o.value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.op)
+}
+
+func isProperPrefix(prefix []string, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayIndex(token string, length int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index >= length || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrPathNotFound, token)
+	}
+	return index, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+	}
+	return doc, nil
+}
+
+// add returns doc with value added at path. Arrays are rebuilt, so the result
+// has to replace doc.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token := path[0]
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			node[token] = value
+			return node, nil
+		}
+		child, ok := node[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		child, err := add(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = child
+		return node, nil
+	case []interface{}:
+		if len(path) == 1 {
+			if token == "-" {
+				return append(node, value), nil
+			}
+			index, err := arrayIndex(token, len(node)+1)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		}
+		index, err := arrayIndex(token, len(node))
+		if err != nil {
+			return nil, err
+		}
+		child, err := add(node[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = child
+		return node, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+}
+
+// remove returns doc without the value at path and the removed value.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: can't remove the whole document", ErrInvalidPatch)
+	}
+	token := path[0]
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		if len(path) == 1 {
+			delete(node, token)
+			return node, child, nil
+		}
+		child, removed, err := remove(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = child
+		return node, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(path) == 1 {
+			removed := node[index]
+			return append(node[:index], node[index+1:]...), removed, nil
+		}
+		child, removed, err := remove(node[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[index] = child
+		return node, removed, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for name, child := range value {
+			result[name] = deepCopy(child)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, child := range value {
+			result[i] = deepCopy(child)
+		}
+		return result
+	}
+	return value
+}
+
+// equal compares JSON values as RFC 6902 defines it for the test operation,
+// numbers are equal when their values are.
+func equal(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okX := new(big.Float).SetString(a.String())
+		y, okY := new(big.Float).SetString(b.String())
+		return okX && okY && x.Cmp(y) == 0
+	}
+	return a == b
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator"
+)
+
+// MaxVarcharLength is the length of the VARCHAR(255) columns.
+const MaxVarcharLength = 255
+
+// registerRules adds the rules for text stored in VARCHAR(255) columns. A
+// nil pointer passes them, it means the field is not set.
+func registerRules(v *validator.Validate) {
+	v.RegisterValidation("title", stringRule(validTitle), true)
+	v.RegisterValidation("varchar", stringRule(fitsVarchar), true)
+	v.RegisterValidation("httpurl", stringRule(validHTTPURL), true)
+}
+
+func stringRule(valid func(s string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		switch field.Kind() {
+		case reflect.String:
+			return valid(field.String())
+		case reflect.Ptr:
+			return field.IsNil()
+		}
+		return false
+	}
+}
+
+// validTitle accepts a non-blank string that fits a VARCHAR column.
+func validTitle(s string) bool {
+	return strings.TrimSpace(s) != "" && fitsVarchar(s)
+}
+
+// validHTTPURL accepts an absolute http or https URL that fits a VARCHAR
+// column.
+func validHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && fitsVarchar(s)
+}
+
+func fitsVarchar(s string) bool {
+	return utf8.RuneCountInString(s) <= MaxVarcharLength
+}
+
+// ruleParam is the parameter reported for rules that have it built in.
+func ruleParam(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "title", "varchar", "httpurl":
+		return strconv.Itoa(MaxVarcharLength)
+	}
+	return fieldErr.Param()
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"reflect"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator"
+)
+
+// translations holds messages by locale and key. The key is the rule, rules
+// whose meaning depends on the kind of the field have a suffix for it. {0}
+// is the field and {1} the parameter of the rule.
+var translations = map[string]map[string]string{
+	"en": {
+		"required":   "{0} is a required field",
+		"title":      "{0} must not be blank and must be at most {1} characters long",
+		"varchar":    "{0} must be at most {1} characters long",
+		"httpurl":    "{0} must be an http or https URL at most {1} characters long",
+		"uuid":       "{0} must be a UUID",
+		"oneof":      "{0} must be one of [{1}]",
+		"min-string": "{0} must be at least {1} characters long",
+		"min-items":  "{0} must contain at least {1} items",
+		"min-number": "{0} must be {1} or greater",
+		"max-string": "{0} must be at most {1} characters long",
+		"max-items":  "{0} must contain at most {1} items",
+		"max-number": "{0} must be {1} or less",
+		"default":    "{0} failed the '{1}' rule",
+	},
+	"ru": {
+		"required":   "поле {0} обязательно для заполнения",
+		"title":      "поле {0} не должно быть пустым, его длина не должна превышать {1}",
+		"varchar":    "длина поля {0} не должна превышать {1}",
+		"httpurl":    "поле {0} должно быть http или https URL длиной не больше {1}",
+		"uuid":       "поле {0} должно быть UUID",
+		"oneof":      "поле {0} должно принимать одно из значений [{1}]",
+		"min-string": "длина поля {0} должна быть не меньше {1}",
+		"min-items":  "количество элементов в поле {0} должно быть не меньше {1}",
+		"min-number": "значение поля {0} должно быть не меньше {1}",
+		"max-string": "длина поля {0} не должна превышать {1}",
+		"max-items":  "количество элементов в поле {0} не должно превышать {1}",
+		"max-number": "значение поля {0} не должно превышать {1}",
+		"default":    "поле {0} не прошло проверку '{1}'",
+	},
+}
+
+func translate(trans ut.Translator, fieldErr validator.FieldError) string {
+	key := fieldErr.Tag()
+	switch key {
+	case "min", "max":
+		key += "-" + sizeKind(fieldErr.Kind())
+	}
+
+	message, err := trans.T(key, fieldErr.Field(), ruleParam(fieldErr))
+	if err != nil {
+		message, _ = trans.T("default", fieldErr.Field(), fieldErr.Tag())
+	}
+	return message
+}
+
+// sizeKind tells what min and max limit for a field of the kind.
+func sizeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return "items"
+	}
+	return "number"
+}
@@ -1,11 +1,48 @@
 package validate
 
 import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator"
+	"golang.org/x/text/language"
 )
 
 type CustomValidator struct {
 	Validator *validator.Validate
+	uni       *ut.UniversalTranslator
+}
+
+// FieldError describes a field that failed validation.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. operations[0].title.
+	Field   string
+	Rule    string
+	Param   string
+	Message string
+}
+
+// NewCustomValidator returns a validator that reports fields by their JSON
+// names, as clients know them, and knows the rules of this module.
+func NewCustomValidator() *CustomValidator {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	registerRules(v)
+
+	return &CustomValidator{
+		Validator: v,
+		uni:       newUniversalTranslator(),
+	}
 }
 
 func (cv *CustomValidator) Validate(i interface{}) error {
@@ -14,3 +51,65 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	}
 	return nil
 }
+
+// FieldErrors describes the fields rejected by Validate in the language
+// preferred by the Accept-Language header. It reports false if err does not
+// come from the validator.
+func (cv *CustomValidator) FieldErrors(err error, acceptLanguage string) ([]FieldError, bool) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+
+	trans, _ := cv.uni.FindTranslator(preferredLanguages(acceptLanguage)...)
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Param:   ruleParam(fieldErr),
+			Message: translate(trans, fieldErr),
+		})
+	}
+	return fields, true
+}
+
+// fieldPath drops the name of the validated struct from the namespace.
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// preferredLanguages lists the languages of the header by preference.
+func preferredLanguages(acceptLanguage string) []string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+
+	languages := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		languages = append(languages, base.String())
+	}
+	return languages
+}
+
+func newUniversalTranslator() *ut.UniversalTranslator {
+	english := en.New()
+	uni := ut.New(english, english, ru.New())
+
+	for locale, messages := range translations {
+		trans, _ := uni.GetTranslator(locale)
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return uni
+}
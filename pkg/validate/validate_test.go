@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOperation struct {
+	Title       *string `json:"title" validate:"title"`
+	Description *string `json:"description" validate:"varchar"`
+}
+
+type testInput struct {
+	Title      string          `json:"title" validate:"required,title"`
+	Operations []testOperation `json:"operations" validate:"max=1,dive"`
+}
+
+func TestCustomValidator_FieldErrors(t *testing.T) {
+	blank := "  "
+	long := strings.Repeat("я", MaxVarcharLength+1)
+	fits := strings.Repeat("я", MaxVarcharLength)
+
+	testTable := []struct {
+		name           string
+		input          testInput
+		acceptLanguage string
+		expected       []FieldError
+	}{
+		{
+			name:  "valid",
+			input: testInput{Title: fits, Operations: []testOperation{{Description: &fits}}},
+		},
+		{
+			name:  "required",
+			input: testInput{},
+			expected: []FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+			},
+		},
+		{
+			name: "nested fields",
+			input: testInput{Title: long, Operations: []testOperation{
+				{Title: &blank, Description: &long},
+			}},
+			expected: []FieldError{
+				{Field: "title", Rule: "title", Param: "255",
+					Message: "title must not be blank and must be at most 255 characters long"},
+				{Field: "operations[0].title", Rule: "title", Param: "255",
+					Message: "title must not be blank and must be at most 255 characters long"},
+				{Field: "operations[0].description", Rule: "varchar", Param: "255",
+					Message: "description must be at most 255 characters long"},
+			},
+		},
+		{
+			name:           "preferred language",
+			input:          testInput{Title: "list", Operations: make([]testOperation, 2)},
+			acceptLanguage: "de;q=1.0, ru-RU;q=0.9, en;q=0.8",
+			expected: []FieldError{
+				{Field: "operations", Rule: "max", Param: "1",
+					Message: "количество элементов в поле operations не должно превышать 1"},
+			},
+		},
+		{
+			name:           "unsupported language",
+			input:          testInput{},
+			acceptLanguage: "de",
+			expected: []FieldError{
+				{Field: "title", Rule: "required", Message: "title is a required field"},
+			},
+		},
+	}
+
+	cv := NewCustomValidator()
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := cv.Validate(testCase.input)
+			if testCase.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			fields, ok := cv.FieldErrors(err, testCase.acceptLanguage)
+			assert.True(t, ok)
+			assert.Equal(t, testCase.expected, fields)
+		})
+	}
+}